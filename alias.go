@@ -0,0 +1,151 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+ Alias Version
+
+ A version which is also known under an alias, like Composer's AliasPackage. Parsing an inline alias like
+ "dev-master as 1.0.0" returns dev-master with its Alias set to 1.0.0, Aliased returns both as an AliasVersion.
+ As an AliasVersion it satisfies constraints for either version, so dev-master can be used for a dependency
+ which requires ^1.0.
+*/
+type AliasVersion struct {
+	// Version is the alias, 1.0.0 for "dev-master as 1.0.0"
+	Version *Version
+	// AliasOf is the aliased version, dev-master for "dev-master as 1.0.0"
+	AliasOf *Version
+}
+
+// NewAliasVersion creates the alias of a version, like the inline alias "version as alias"
+func NewAliasVersion(version *Version, alias *Version) *AliasVersion {
+	return &AliasVersion{Version: alias, AliasOf: version}
+}
+
+// Aliased returns the version with its inline alias, or nil if it has no alias
+func (v *Version) Aliased() *AliasVersion {
+	if nil == v.Alias {
+		return nil
+	}
+
+	return NewAliasVersion(v, v.Alias)
+}
+
+// Matches checks if the constraint matches either the alias or the aliased version
+func (a *AliasVersion) Matches(c *Constraint) bool {
+	return c.Matches(a.Version) || c.Matches(a.AliasOf)
+}
+
+func (a *AliasVersion) String() string {
+	return a.Version.String() + " (alias of " + a.AliasOf.String() + ")"
+}
+
+// Alias returns a copy of the inline alias of a single version constraint, 1.0.0 for "dev-master as 1.0.0",
+// or nil
+func (c *Constraint) Alias() *Version {
+	if KindComparison != c.Kind() || nil == c.version.Alias {
+		return nil
+	}
+
+	return c.version.Alias.copy()
+}
+
+/*
+ Branch Alias Map
+
+ Maps dev branches to the numeric branch they are aliased as, like "extra.branch-alias" in composer.json.
+ The keys are normalized branch versions, e.g. "9999999-dev" for dev-master or "2.9999999.9999999.9999999-dev"
+ for 2.x-dev, so dev-master with the alias 2.1.x-dev is installable for the constraint ^2.1.
+*/
+type BranchAliasMap map[string]*Version
+
+// NewBranchAliasMap parses a branch alias map like {"dev-master": "2.1.x-dev"}. Like Composer, the alias must
+// be a numeric dev branch, the aliased version must be a dev version, and a numeric branch can only be
+// aliased as a branch with the same numeric prefix
+func NewBranchAliasMap(aliases map[string]string) (BranchAliasMap, error) {
+	branches := make([]string, 0, len(aliases))
+	for branch := range aliases {
+		branches = append(branches, branch)
+	}
+
+	// validate in a stable order, so the same error is returned for the same map
+	sort.Strings(branches)
+
+	m := make(BranchAliasMap, len(aliases))
+
+	for _, branch := range branches {
+		alias, err := parseBranchAlias(branch, aliases[branch])
+
+		if nil != err {
+			return nil, err
+		}
+
+		version, _ := NewVersion(branch)
+		m[version.String()] = alias
+	}
+
+	return m, nil
+}
+
+func parseBranchAlias(branch string, target string) (*Version, error) {
+	version, err := NewVersion(branch)
+
+	if nil != err || !version.isDevBranch() {
+		return nil, fmt.Errorf("invalid branch alias %s => %s: %s is not a dev branch", branch, target, branch)
+	}
+
+	if !strings.HasSuffix(target, "-dev") {
+		return nil, fmt.Errorf("invalid branch alias %s => %s: the alias must end with -dev", branch, target)
+	}
+
+	alias, err := NormalizeBranch(strings.TrimSuffix(target, "-dev"))
+
+	if nil != err || alias.isBranch {
+		return nil, fmt.Errorf("invalid branch alias %s => %s: %s is not a numeric branch", branch, target, target)
+	}
+
+	// a numeric branch can only be aliased as one of its versions, 1.x-dev as 1.5.x-dev but not as 2.0.x-dev
+	if branchPrefix, ok := ParseNumericAliasPrefix(branch); ok {
+		if targetPrefix, ok := ParseNumericAliasPrefix(target); ok && !strings.HasPrefix(targetPrefix, branchPrefix) {
+			return nil, fmt.Errorf("invalid branch alias %s => %s: the alias must start with %s", branch, target, branchPrefix)
+		}
+	}
+
+	alias.Original = target
+
+	return alias, nil
+}
+
+// isDevBranch checks if the version is a branch like dev-foo, or a numeric branch like dev-master or 1.0.x-dev
+// whose wildcard segments are 9999999. Dev pre-releases like 1.0.0-dev are not branches
+func (v *Version) isDevBranch() bool {
+	if v.isBranch {
+		return true
+	}
+
+	return "dev" == v.Stability && !v.isDate && !v.isBumpable()
+}
+
+// Alias returns the version under its branch alias, or nil if the version has no branch alias
+func (m BranchAliasMap) Alias(version *Version) *AliasVersion {
+	alias, ok := m[version.String()]
+
+	if !ok {
+		return nil
+	}
+
+	return NewAliasVersion(version, alias)
+}
+
+// Matches checks if the constraint matches the version, or the branch alias of the version
+func (m BranchAliasMap) Matches(c *Constraint, version *Version) bool {
+	if alias := m.Alias(version); nil != alias {
+		return alias.Matches(c)
+	}
+
+	return c.Matches(version)
+}
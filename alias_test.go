@@ -0,0 +1,178 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVersionInlineAlias(t *testing.T) {
+	v, err := NewVersion("dev-master as 1.0.0")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "9999999-dev", v.String())
+	assert.Equal(t, "dev-master as 1.0.0", v.Pretty())
+
+	if assert.NotNil(t, v.Alias) {
+		assert.Equal(t, "1.0.0.0", v.Alias.String())
+		assert.Equal(t, "1.0.0", v.Alias.Pretty())
+		assert.Nil(t, v.Alias.Alias)
+	}
+
+	if aliased := v.Aliased(); assert.NotNil(t, aliased) {
+		assert.True(t, v == aliased.AliasOf)
+		assert.True(t, v.Alias == aliased.Version)
+	}
+
+	var decoded Version
+	if assert.NoError(t, decoded.UnmarshalText([]byte("dev-master as 1.0.0"))) && assert.NotNil(t, decoded.Aliased()) {
+		assert.True(t, &decoded == decoded.Aliased().AliasOf)
+	}
+
+	v, err = NewVersion("1.0.0")
+	if assert.NoError(t, err) {
+		assert.Nil(t, v.Alias)
+		assert.Nil(t, v.Aliased())
+	}
+}
+
+func TestVersionInlineAliasFails(t *testing.T) {
+	cases := []struct {
+		version string
+		failing string
+	}{
+		{"dev-master as foo", "foo"},
+		{"1.0.0-meh as 1.0.0", "1.0.0-meh"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			_, err := NewVersion(tc.version)
+
+			parseErr, ok := err.(*VersionParseError)
+			if assert.True(t, ok, "expected a *VersionParseError, got %T", err) {
+				assert.Equal(t, tc.version, parseErr.Input)
+				assert.Equal(t, tc.failing, parseErr.Version)
+			}
+		})
+	}
+}
+
+func TestAliasVersionMatches(t *testing.T) {
+	v, _ := NewVersion("dev-main as 1.2.0")
+	alias := v.Aliased()
+
+	cases := []struct {
+		constraint string
+		matches    bool
+	}{
+		{"^1.0", true},
+		{"dev-main", true},
+		{"1.2.0", true},
+		{"^2.0", false},
+		{"dev-feature", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			c, err := NewConstraint(tc.constraint)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.matches, alias.Matches(c))
+			}
+		})
+	}
+
+	assert.Equal(t, "1.2.0.0 (alias of dev-main)", alias.String())
+}
+
+func TestConstraintAlias(t *testing.T) {
+	c, err := NewConstraint("dev-master as 1.0.0")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.NotNil(t, c.Alias()) {
+		assert.Equal(t, "1.0.0.0", c.Alias().String())
+	}
+
+	assert.Equal(t, "9999999-dev", c.Version().String())
+
+	c, _ = NewConstraint("^1.0")
+	assert.Nil(t, c.Alias())
+}
+
+func TestBranchAliasMap(t *testing.T) {
+	aliases, err := NewBranchAliasMap(map[string]string{
+		"dev-master":  "2.1.x-dev",
+		"1.x-dev":     "1.5.x-dev",
+		"dev-feature": "3.0.x-dev",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cases := []struct {
+		version    string
+		constraint string
+		matches    bool
+	}{
+		{"dev-master", "^2.1", true},
+		{"master", "^2.1", true},
+		{"dev-master", "~2.1.0", true},
+		{"dev-master", "^2.2", false},
+		{"dev-master", "dev-master", true},
+		{"1.x-dev", "^1.5", true},
+		{"1.x-dev", "1.x-dev", true},
+		{"dev-feature", "3.0.*@dev", true},
+		{"dev-other", "^2.1", false},
+		{"dev-other", "dev-other", true},
+		{"2.1.0", "^2.1", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version+" "+tc.constraint, func(t *testing.T) {
+			v, err := NewVersion(tc.version)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			c, err := NewConstraint(tc.constraint)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.matches, aliases.Matches(c, v))
+			}
+		})
+	}
+
+	master, _ := NewVersion("dev-master")
+	if alias := aliases.Alias(master); assert.NotNil(t, alias) {
+		assert.Equal(t, "2.1.9999999.9999999-dev (alias of 9999999-dev)", alias.String())
+		assert.Equal(t, "2.1.x-dev", alias.Version.Pretty())
+	}
+
+	other, _ := NewVersion("dev-other")
+	assert.Nil(t, aliases.Alias(other))
+}
+
+func TestBranchAliasMapFails(t *testing.T) {
+	cases := []struct {
+		aliases map[string]string
+		err     string
+	}{
+		{map[string]string{"dev-master": "2.1.x"}, "invalid branch alias dev-master => 2.1.x: the alias must end with -dev"},
+		{map[string]string{"dev-master": "dev-foo-dev"}, "invalid branch alias dev-master => dev-foo-dev: dev-foo-dev is not a numeric branch"},
+		{map[string]string{"1.0.0": "1.0.x-dev"}, "invalid branch alias 1.0.0 => 1.0.x-dev: 1.0.0 is not a dev branch"},
+		{map[string]string{"1.0.0-dev": "1.0.x-dev"}, "invalid branch alias 1.0.0-dev => 1.0.x-dev: 1.0.0-dev is not a dev branch"},
+		{map[string]string{"dev-b": "2.x", "dev-a": "foo"}, "invalid branch alias dev-a => foo: the alias must end with -dev"},
+		{map[string]string{"1.x-dev": "2.0.x-dev"}, "invalid branch alias 1.x-dev => 2.0.x-dev: the alias must start with 1."},
+		{map[string]string{"1.2.x-dev": "1.3.x-dev"}, "invalid branch alias 1.2.x-dev => 1.3.x-dev: the alias must start with 1.2."},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.err, func(t *testing.T) {
+			_, err := NewBranchAliasMap(tc.aliases)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
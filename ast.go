@@ -0,0 +1,121 @@
+package semver
+
+import (
+	"fmt"
+)
+
+// Kind is the type of a node in a parsed constraint tree
+type Kind int
+
+const (
+	// KindMatchAll matches every version, e.g. "*"
+	KindMatchAll Kind = iota
+	// KindMatchNone never matches a version
+	KindMatchNone
+	// KindComparison compares a single version with an operator, e.g. ">= 1.2.0.0-dev"
+	KindComparison
+	// KindAnd matches if all children match
+	KindAnd
+	// KindOr matches if any child matches
+	KindOr
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindMatchAll:
+		return "match-all"
+	case KindMatchNone:
+		return "match-none"
+	case KindComparison:
+		return "comparison"
+	case KindAnd:
+		return "and"
+	case KindOr:
+		return "or"
+	}
+
+	return "unknown"
+}
+
+// NewComparisonConstraint creates a single comparison node. The operator can be any of
+// ==, =, !=, <>, <, <=, > or >=
+func NewComparisonConstraint(operator string, version *Version) (*Constraint, error) {
+	op, ok := operatorMap[operator]
+
+	if !ok {
+		return nil, fmt.Errorf("invalid operator %s", operator)
+	}
+
+	if nil == version {
+		return nil, fmt.Errorf("missing version for operator %s", operator)
+	}
+
+	return &Constraint{operator: op, version: version, conjunctive: true}, nil
+}
+
+// NewMultiConstraint combines constraints into an AND node if conjunctive is true, or an OR node otherwise.
+// Like Composer's MultiConstraint::create, an AND of no constraints matches every version and an OR of no
+// constraints matches none
+func NewMultiConstraint(constraints []*Constraint, conjunctive bool) *Constraint {
+	if 0 == len(constraints) && conjunctive {
+		return &Constraint{isEmpty: true}
+	}
+
+	return &Constraint{constraints: append([]*Constraint{}, constraints...), conjunctive: conjunctive}
+}
+
+// Kind returns the type of the node
+func (c *Constraint) Kind() Kind {
+	if c.isEmpty {
+		return KindMatchAll
+	}
+
+	if len(c.constraints) > 0 {
+		if c.conjunctive {
+			return KindAnd
+		}
+
+		return KindOr
+	}
+
+	if nil == c.version {
+		return KindMatchNone
+	}
+
+	return KindComparison
+}
+
+// Operator returns the normalized operator of a comparison node, or an empty string for other nodes
+func (c *Constraint) Operator() string {
+	if KindComparison != c.Kind() {
+		return ""
+	}
+
+	return c.operator
+}
+
+// Version returns a copy of the version of a comparison node, or nil for other nodes
+func (c *Constraint) Version() *Version {
+	if KindComparison != c.Kind() {
+		return nil
+	}
+
+	return c.version.copy()
+}
+
+// Constraints returns the children of an AND or OR node
+func (c *Constraint) Constraints() []*Constraint {
+	return append([]*Constraint{}, c.constraints...)
+}
+
+// Walk traverses the constraint tree depth-first, calling fn for every node. If fn returns false,
+// the children of that node are skipped
+func (c *Constraint) Walk(fn func(*Constraint) bool) {
+	if !fn(c) {
+		return
+	}
+
+	for _, constraint := range c.constraints {
+		constraint.Walk(fn)
+	}
+}
@@ -0,0 +1,120 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConstraintKind(t *testing.T) {
+	cases := []struct {
+		constraint string
+		kind       Kind
+	}{
+		{"*", KindMatchAll},
+		{">=1.0", KindComparison},
+		{"dev-foo", KindComparison},
+		{"^1.0", KindAnd},
+		{">2.0 <=3.0", KindAnd},
+		{"^0.2 || ^1.0", KindOr},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			c, err := NewConstraint(tc.constraint)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.kind, c.Kind())
+			}
+		})
+	}
+
+	a, _ := NewConstraint("^1.0")
+	b, _ := NewConstraint("^2.0")
+	assert.Equal(t, KindMatchNone, a.Intersect(b).Kind())
+}
+
+func TestConstraintAccessors(t *testing.T) {
+	c, _ := NewConstraint(">2.0 <=3.0")
+
+	assert.Equal(t, "", c.Operator())
+	assert.Nil(t, c.Version())
+
+	children := c.Constraints()
+	if assert.Len(t, children, 2) {
+		assert.Equal(t, ">", children[0].Operator())
+		assert.Equal(t, "2.0.0.0", children[0].Version().String())
+		assert.Equal(t, "<=", children[1].Operator())
+		assert.Equal(t, "3.0.0.0", children[1].Version().String())
+	}
+
+	// the accessors must not allow the parsed constraint to be modified
+	children[0].Version().Major = 5
+	children[0] = nil
+	assert.Equal(t, "[> 2.0.0.0 <= 3.0.0.0]", c.String())
+}
+
+func TestConstraintAccessorsCopyAlias(t *testing.T) {
+	c, _ := NewConstraint("dev-master as 1.0.0")
+
+	c.Version().Major = 5
+	c.Version().Alias.Major = 7
+	c.Alias().Minor = 3
+
+	assert.Equal(t, "== 9999999-dev", c.String())
+	assert.Equal(t, "1.0.0.0", c.Alias().String())
+	assert.Equal(t, "1.0.0.0", c.Version().Alias.String())
+}
+
+func TestConstraintWalk(t *testing.T) {
+	c, _ := NewConstraint("^0.2 || >=1.0 <1.5 !=1.2.0")
+
+	var kinds []string
+	c.Walk(func(node *Constraint) bool {
+		kinds = append(kinds, node.Kind().String()+" "+node.Operator())
+		return true
+	})
+
+	assert.Equal(t, []string{"or ", "and ", "comparison >=", "comparison <", "and ", "comparison >=", "comparison <", "comparison !="}, kinds)
+
+	var visited int
+	c.Walk(func(node *Constraint) bool {
+		visited++
+		return KindOr == node.Kind()
+	})
+
+	assert.Equal(t, 3, visited)
+}
+
+func TestRewriteConstraint(t *testing.T) {
+	v, _ := NewVersion("1.5.0")
+
+	_, err := NewComparisonConstraint("~", v)
+	assert.Error(t, err)
+
+	ne, err := NewComparisonConstraint("<>", v)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	caret, _ := NewConstraint("^1.0")
+	c := NewMultiConstraint([]*Constraint{caret, ne}, true)
+
+	assert.Equal(t, KindAnd, c.Kind())
+	assert.Equal(t, "[[>= 1.0.0.0-dev < 2.0.0.0-dev] != 1.5.0.0]", c.String())
+	assert.False(t, c.Matches(v))
+}
+
+func TestNewMultiConstraintEmpty(t *testing.T) {
+	v, _ := NewVersion("1.5.0")
+
+	c := NewMultiConstraint(nil, true)
+
+	assert.Equal(t, KindMatchAll, c.Kind())
+	assert.True(t, c.Matches(v))
+	assert.True(t, c.Intervals().Matches(v))
+
+	c = NewMultiConstraint([]*Constraint{}, false)
+
+	assert.Equal(t, KindMatchNone, c.Kind())
+	assert.False(t, c.Matches(v))
+	assert.False(t, c.IsSatisfiable())
+}
@@ -0,0 +1,74 @@
+package semver
+
+import (
+	"fmt"
+)
+
+// Bound is the lower or upper limit of the versions matched by a constraint
+type Bound struct {
+	Version   *Version
+	Inclusive bool
+}
+
+// IsZero checks if the bound is the lowest possible version, i.e. there is no lower limit
+func (b Bound) IsZero() bool {
+	return b.Inclusive && Equal == compare(b.Version, fromZero().version)
+}
+
+// IsPositiveInfinity checks if the bound is above every possible version, i.e. there is no upper limit
+func (b Bound) IsPositiveInfinity() bool {
+	return !b.Inclusive && Equal == compare(b.Version, untilPositiveInfinity().version)
+}
+
+// IsUnbounded checks if the bound is either zero or positive infinity
+func (b Bound) IsUnbounded() bool {
+	return b.IsZero() || b.IsPositiveInfinity()
+}
+
+func (b Bound) String() string {
+	if b.Inclusive {
+		return fmt.Sprintf("%s [inclusive]", b.Version.String())
+	}
+
+	return fmt.Sprintf("%s [exclusive]", b.Version.String())
+}
+
+// LowerBound returns the lowest version matched by the constraint. Dev branches are not ordered against
+// numeric versions, so a constraint which only matches branches has a zero lower bound. Like Composer's
+// MatchNoneConstraint, a constraint which matches no version has a positive infinity lower bound
+func (c *Constraint) LowerBound() Bound {
+	intervals := generateIntervals(c)
+
+	if !intervals.isSatisfiable() {
+		return newBound(untilPositiveInfinity())
+	}
+
+	if 0 == len(intervals.Numeric) {
+		return newBound(fromZero())
+	}
+
+	return newBound(intervals.Numeric[0].Start)
+}
+
+// UpperBound returns the highest version matched by the constraint. Dev branches are not ordered against
+// numeric versions, so a constraint which only matches branches has a positive infinity upper bound. A
+// constraint which matches no version has a zero upper bound
+func (c *Constraint) UpperBound() Bound {
+	intervals := generateIntervals(c)
+
+	if !intervals.isSatisfiable() {
+		return newBound(fromZero())
+	}
+
+	if 0 == len(intervals.Numeric) {
+		return newBound(untilPositiveInfinity())
+	}
+
+	return newBound(intervals.Numeric[len(intervals.Numeric)-1].End)
+}
+
+func newBound(c *Constraint) Bound {
+	version := *c.version
+
+	return Bound{Version: &version, Inclusive: ">=" == c.operator || "<=" == c.operator}
+}
@@ -0,0 +1,58 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConstraintBounds(t *testing.T) {
+	cases := []struct {
+		constraint string
+		lower      string
+		upper      string
+	}{
+		{"^1.2", "1.2.0.0-dev [inclusive]", "2.0.0.0-dev [exclusive]"},
+		{"~1.2.3", "1.2.3.0-dev [inclusive]", "1.3.0.0-dev [exclusive]"},
+		{"1.2 - 2.1.0", "1.2.0.0-dev [inclusive]", "2.1.0.0 [inclusive]"},
+		{"1.2.3", "1.2.3.0 [inclusive]", "1.2.3.0 [inclusive]"},
+		{">1.0 <=2.0", "1.0.0.0 [exclusive]", "2.0.0.0 [inclusive]"},
+		{"^1.0 || ^3.0", "1.0.0.0-dev [inclusive]", "4.0.0.0-dev [exclusive]"},
+		{">=1.5", "1.5.0.0-dev [inclusive]", "9223372036854775807.0.0.0 [exclusive]"},
+		{"<1.5", "0.0.0.0-dev [inclusive]", "1.5.0.0-dev [exclusive]"},
+		{"dev-foo", "0.0.0.0-dev [inclusive]", "9223372036854775807.0.0.0 [exclusive]"},
+		{">=2.0 <1.0", "9223372036854775807.0.0.0 [exclusive]", "0.0.0.0-dev [inclusive]"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			c, err := NewConstraint(tc.constraint)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.lower, c.LowerBound().String())
+				assert.Equal(t, tc.upper, c.UpperBound().String())
+			}
+		})
+	}
+}
+
+func TestBoundUnbounded(t *testing.T) {
+	c, _ := NewConstraint("*")
+
+	assert.True(t, c.LowerBound().IsZero())
+	assert.True(t, c.LowerBound().IsUnbounded())
+	assert.False(t, c.LowerBound().IsPositiveInfinity())
+	assert.True(t, c.UpperBound().IsPositiveInfinity())
+	assert.True(t, c.UpperBound().IsUnbounded())
+
+	c, _ = NewConstraint("^1.2")
+
+	assert.False(t, c.LowerBound().IsUnbounded())
+	assert.False(t, c.UpperBound().IsUnbounded())
+	assert.Equal(t, 1, c.LowerBound().Version.Major)
+	assert.Equal(t, 2, c.LowerBound().Version.Minor)
+	assert.Equal(t, 2, c.UpperBound().Version.Major)
+
+	c, _ = NewConstraint(">=2.0 <1.0")
+
+	assert.True(t, c.LowerBound().IsPositiveInfinity())
+	assert.True(t, c.UpperBound().IsZero())
+}
@@ -0,0 +1,211 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NextMajor returns the next major version, 1.2.3 becomes 2.0.0. Bumping a pre-release whose lower segments
+// are already zero releases it instead, so the next major version of 2.0.0-beta2 is 2.0.0. Branches and dates
+// have no numeric segments to bump and return an error
+func (v *Version) NextMajor() (*Version, error) {
+	return v.bump(0)
+}
+
+// NextMinor returns the next minor version, 1.2.3 becomes 1.3.0 and 1.3.0-RC1 is released as 1.3.0
+func (v *Version) NextMinor() (*Version, error) {
+	return v.bump(1)
+}
+
+// NextPatch returns the next patch version, 1.2.3 becomes 1.2.4 and 1.2.4-beta2 is released as 1.2.4
+func (v *Version) NextPatch() (*Version, error) {
+	return v.bump(2)
+}
+
+// NextExtra returns the next version of the fourth segment, 1.2.3.4 becomes 1.2.3.5
+func (v *Version) NextExtra() (*Version, error) {
+	return v.bump(3)
+}
+
+/*
+ Next Stability
+
+ Returns the next pre-release with the given stability, following Composer's conventions:
+ 1.2.0-beta2 -> 1.2.0-beta3 -> 1.2.0-RC1 -> 1.2.0. The next pre-release of a stable version starts
+ on the next patch version, so 1.2.0 becomes 1.2.1-beta1. It fails if the stability is lower than
+ the stability of the version, as that would result in a lower version.
+*/
+func (v *Version) NextStability(stability string) (*Version, error) {
+	if !v.isBumpable() {
+		return nil, fmt.Errorf("unable to bump the stability of %s", v.String())
+	}
+
+	r := currentStabilities()
+	stability = r.expandStability(stability)
+
+	if !r.isKnown(stability) {
+		return nil, fmt.Errorf("invalid stability %s", stability)
+	}
+
+	current := v.releaseStability()
+
+	if "stable" == current {
+		if "stable" == stability {
+			return nil, fmt.Errorf("version %s is already stable", v.String())
+		}
+
+		next, err := v.bump(2)
+
+		if nil != err {
+			return nil, err
+		}
+
+		next.setPreRelease(stability, "1")
+		next.Original = next.prettyString()
+
+		return next, nil
+	}
+
+	next := v.copyNumbers()
+
+	switch compareStability(stability, current) {
+	case LessThan:
+		return nil, fmt.Errorf("unable to bump %s to the lower stability %s", v.String(), stability)
+	case Equal:
+		next.Stability = stability
+		next.PreRelease = incrementIdentifiers(v.PreReleaseIdentifiers())
+	case GreaterThan:
+		if "stable" != stability {
+			next.setPreRelease(stability, "1")
+		}
+	}
+
+	next.Original = next.prettyString()
+
+	return next, nil
+}
+
+// bump increments the segment at the position and resets all lower segments
+func (v *Version) bump(position int) (*Version, error) {
+	if !v.isBumpable() {
+		return nil, fmt.Errorf("unable to bump %s, branches and dates have no numeric segments", v.String())
+	}
+
+	next := v.copyNumbers()
+	segments := []*int{&next.Major, &next.Minor, &next.Patch, &next.Extra}
+
+	released := true
+	for _, segment := range segments[position+1:] {
+		if 0 != *segment {
+			released = false
+		}
+
+		*segment = 0
+	}
+
+	// a pre-release of 2.0.0 is bumped to its release, not to 3.0.0
+	if !released || "stable" == v.releaseStability() {
+		*segments[position]++
+	}
+
+	next.Original = next.prettyString()
+
+	return next, nil
+}
+
+// isBumpable checks if the version has numeric segments to bump. Branches and dates don't, and neither do
+// numeric branches like dev-master or 1.0.x-dev, as their wildcard segments are 9999999
+func (v *Version) isBumpable() bool {
+	if v.isBranch || v.isDate {
+		return false
+	}
+
+	for _, segment := range []int{v.Major, v.Minor, v.Patch, v.Extra} {
+		if 9999999 == segment {
+			return false
+		}
+	}
+
+	return true
+}
+
+// copyNumbers returns a stable release with the same numeric segments
+func (v *Version) copyNumbers() *Version {
+	return &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Extra: v.Extra, isStrict: v.isStrict}
+}
+
+// releaseStability returns the stability of the version, where patch releases are stable
+func (v *Version) releaseStability() string {
+	if "" == v.Stability || "patch" == v.Stability || "stable" == v.Stability {
+		if "dev" == v.State {
+			return "dev"
+		}
+
+		return "stable"
+	}
+
+	return v.Stability
+}
+
+func (v *Version) setPreRelease(stability string, number string) {
+	v.Stability = stability
+
+	if v.isStrict {
+		v.PreRelease = strings.ToLower(stability) + "." + number
+	} else {
+		v.PreRelease = number
+	}
+}
+
+// prettyString formats the version the way it would be tagged, e.g. 1.2.0-beta3
+func (v *Version) prettyString() string {
+	if v.isStrict || v.isBranch || v.isDate || 9999999 == v.Major {
+		return v.String()
+	}
+
+	pretty := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if 0 != v.Extra {
+		pretty += fmt.Sprintf(".%d", v.Extra)
+	}
+
+	if "" != v.Stability {
+		pretty += "-" + v.Stability + v.PreRelease
+	}
+
+	return pretty
+}
+
+// incrementIdentifiers increments the last numeric identifier, a missing number counts as 1
+func incrementIdentifiers(identifiers []string) string {
+	if 0 == len(identifiers) {
+		return "2"
+	}
+
+	last := len(identifiers) - 1
+	result := append([]string{}, identifiers...)
+
+	if !isNumericIdentifier(result[last]) {
+		return strings.Join(append(result, "2"), ".")
+	}
+
+	result[last] = incrementDigits(result[last])
+
+	return strings.Join(result, ".")
+}
+
+// incrementDigits adds one to a decimal number of any length
+func incrementDigits(number string) string {
+	digits := []byte(number)
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		if '9' != digits[i] {
+			digits[i]++
+			return string(digits)
+		}
+
+		digits[i] = '0'
+	}
+
+	return "1" + string(digits)
+}
@@ -0,0 +1,154 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVersionBump(t *testing.T) {
+	cases := []struct {
+		version string
+		major   string
+		minor   string
+		patch   string
+		extra   string
+	}{
+		{"1.2.3", "2.0.0", "1.3.0", "1.2.4", "1.2.3.1"},
+		{"v1.2.3.4", "2.0.0", "1.3.0", "1.2.4", "1.2.3.5"},
+		{"0.0.0", "1.0.0", "0.1.0", "0.0.1", "0.0.0.1"},
+		{"1.2.3-beta2", "2.0.0", "1.3.0", "1.2.3", "1.2.3"},
+		{"2.0.0-beta2", "2.0.0", "2.0.0", "2.0.0", "2.0.0"},
+		{"2.1.0-RC1", "3.0.0", "2.1.0", "2.1.0", "2.1.0"},
+		{"1.2.3-patch1", "2.0.0", "1.3.0", "1.2.4", "1.2.3.1"},
+		{"1.2.3+build.5", "2.0.0", "1.3.0", "1.2.4", "1.2.3.1"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			v, err := NewVersion(tc.version)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			bumps := []func() (*Version, error){v.NextMajor, v.NextMinor, v.NextPatch, v.NextExtra}
+
+			for i, expected := range []string{tc.major, tc.minor, tc.patch, tc.extra} {
+				next, err := bumps[i]()
+				if assert.NoError(t, err) {
+					assert.Equal(t, expected, next.Original)
+					assert.True(t, next.GreaterThan(v), "%s must be greater than %s", next, v)
+				}
+			}
+		})
+	}
+}
+
+func TestVersionBumpNormalizes(t *testing.T) {
+	v, _ := NewVersion("1.2.0-beta2")
+	next, err := v.NextMajor()
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2.0.0.0", next.String())
+		assert.Equal(t, "1.2.0-beta2", v.Original, "the version must not be modified")
+	}
+}
+
+func TestVersionBumpFails(t *testing.T) {
+	for _, version := range []string{"dev-foo", "dev-master", "1.0.x-dev", "2.x-dev", "20100102"} {
+		t.Run(version, func(t *testing.T) {
+			v, err := NewVersion(version)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for _, bump := range []func() (*Version, error){v.NextMajor, v.NextMinor, v.NextPatch, v.NextExtra} {
+				_, err := bump()
+				assert.EqualError(t, err, "unable to bump "+v.String()+", branches and dates have no numeric segments")
+			}
+
+			_, err = v.NextStability("beta")
+			assert.EqualError(t, err, "unable to bump the stability of "+v.String())
+		})
+	}
+}
+
+func TestVersionNextStability(t *testing.T) {
+	cases := []struct {
+		version   string
+		stability string
+		next      string
+	}{
+		{"1.2.0-beta2", "beta", "1.2.0-beta3"},
+		{"1.2.0-beta3", "RC", "1.2.0-RC1"},
+		{"1.2.0-RC1", "rc", "1.2.0-RC2"},
+		{"1.2.0-RC2", "stable", "1.2.0"},
+		{"1.2.0-beta", "beta", "1.2.0-beta2"},
+		{"1.2.0-beta2.9", "b", "1.2.0-beta2.10"},
+		{"1.2.0-alpha9", "alpha", "1.2.0-alpha10"},
+		{"1.2.0-dev", "alpha", "1.2.0-alpha1"},
+		{"1.2.0", "beta", "1.2.1-beta1"},
+		{"1.2.0-beta999999999999999999999", "beta", "1.2.0-beta1000000000000000000000"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version+" "+tc.stability, func(t *testing.T) {
+			v, err := NewVersion(tc.version)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			next, err := v.NextStability(tc.stability)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.next, next.Original)
+				assert.True(t, next.GreaterThan(v), "%s must be greater than %s", next, v)
+
+				parsed, err := NewVersion(next.Original)
+				if assert.NoError(t, err) {
+					assert.Equal(t, parsed.String(), next.String())
+				}
+			}
+		})
+	}
+}
+
+func TestVersionNextStabilityStrict(t *testing.T) {
+	v, _ := NewStrictVersion("1.2.0-beta.2")
+
+	next, err := v.NextStability("beta")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1.2.0-beta.3", next.String())
+	}
+
+	next, err = v.NextStability("RC")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1.2.0-rc.1", next.String())
+		assert.True(t, next.GreaterThan(v))
+	}
+
+	next, err = v.NextMajor()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2.0.0", next.String())
+	}
+}
+
+func TestVersionNextStabilityFails(t *testing.T) {
+	cases := []struct {
+		version   string
+		stability string
+		err       string
+	}{
+		{"1.2.0-RC1", "beta", "unable to bump 1.2.0.0-RC1 to the lower stability beta"},
+		{"1.2.0", "stable", "version 1.2.0.0 is already stable"},
+		{"1.2.0", "nightly", "invalid stability nightly"},
+		{"dev-foo", "beta", "unable to bump the stability of dev-foo"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version+" "+tc.stability, func(t *testing.T) {
+			v, _ := NewVersion(tc.version)
+
+			_, err := v.NextStability(tc.stability)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
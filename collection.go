@@ -0,0 +1,44 @@
+package semver
+
+// Collection is a list of versions which can be sorted with the sort package
+type Collection []*Version
+
+func (c Collection) Len() int {
+	return len(c)
+}
+
+func (c Collection) Less(i, j int) bool {
+	return LessThan == compare(c[i], c[j])
+}
+
+func (c Collection) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+// NewCollection parses all versions, it fails on the first version which can't be parsed
+func NewCollection(versions []string) (Collection, error) {
+	collection := make(Collection, 0, len(versions))
+
+	for _, version := range versions {
+		v, err := NewVersion(version)
+
+		if nil != err {
+			return nil, err
+		}
+
+		collection = append(collection, v)
+	}
+
+	return collection, nil
+}
+
+// Strings returns the original strings of the versions in the collection
+func (c Collection) Strings() []string {
+	versions := make([]string, 0, len(c))
+
+	for _, v := range c {
+		versions = append(versions, v.Original)
+	}
+
+	return versions
+}
@@ -0,0 +1,23 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestCollectionSort(t *testing.T) {
+	collection, err := NewCollection([]string{"1.2.0", "v1.0.0", "1.2.0-beta2", "0.9", "1.2.0-beta10", "1.2.0-RC1"})
+
+	if assert.NoError(t, err) {
+		sort.Sort(collection)
+
+		assert.Equal(t, []string{"0.9", "v1.0.0", "1.2.0-beta2", "1.2.0-beta10", "1.2.0-RC1", "1.2.0"}, collection.Strings())
+	}
+}
+
+func TestNewCollectionFails(t *testing.T) {
+	_, err := NewCollection([]string{"1.0.0", "1.0.0-meh"})
+
+	assert.EqualError(t, err, "unable to parse version 1.0.0-meh")
+}
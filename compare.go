@@ -1,5 +1,9 @@
 package semver
 
+import (
+	"strings"
+)
+
 const (
 	LessThan = iota - 1
 	Equal
@@ -53,45 +57,163 @@ func (a *Version) Compare(b *Version, operator string) bool {
 	return false
 }
 
+/*
+ Compare
+
+ Gives a total order of all versions. Dev branches (dev-feature-x) have no numeric segments, so like
+ Composer's VersionConstraint::matchSpecific they are only equal to a branch with the same name. Branches
+ are sorted by name, before any other version. Dates are compared by their numeric components. The order of
+ branches is only used for sorting, Constraint.Matches never matches a branch with <, <=, > or >=.
+*/
 func compare(a *Version, b *Version) int {
-	if d := comparePart(a.major(), b.major()); d != Equal {
-		return d
+	if a.isBranch || b.isBranch {
+		if !b.isBranch {
+			return LessThan
+		}
+
+		if !a.isBranch {
+			return GreaterThan
+		}
+
+		return compareStrings(a.String(), b.String())
 	}
 
-	if d := comparePart(a.minor(), b.minor()); d != Equal {
-		return d
+	if a.isDate || b.isDate {
+		if d := compareParts(a.numericParts(), b.numericParts()); d != Equal {
+			return d
+		}
+	} else {
+		if d := comparePart(a.major(), b.major()); d != Equal {
+			return d
+		}
+
+		if d := comparePart(a.minor(), b.minor()); d != Equal {
+			return d
+		}
+
+		if d := comparePart(a.patch(), b.patch()); d != Equal {
+			return d
+		}
+
+		if d := comparePart(a.extra(), b.extra()); d != Equal {
+			return d
+		}
 	}
 
-	if d := comparePart(a.patch(), b.patch()); d != Equal {
-		return d
+	if a.isStrict && b.isStrict {
+		return comparePreRelease(a.PreRelease, b.PreRelease)
 	}
 
 	if d := compareStability(a.stability(), b.stability()); d != Equal {
 		return d
 	}
 
-	aPre := a.pre()
-	bPre := b.pre()
+	if a.PreRelease == b.PreRelease {
+		return Equal
+	}
+
+	return compareIdentifiers(a.pre(), b.pre())
+}
+
+/*
+ Pre-release precedence
+
+ Compares the pre-release of two strict SemVer versions. A version without pre-release has a higher
+ precedence, otherwise the dot separated identifiers are compared from left to right: numeric identifiers
+ numerically, alphanumeric identifiers in ASCII order, and numeric identifiers always have a lower
+ precedence than alphanumeric ones. If all identifiers are equal, the longer pre-release is greater.
+*/
+func comparePreRelease(a string, b string) int {
+	if a == b {
+		return Equal
+	}
 
-	if aPre > bPre {
+	if "" == a {
 		return GreaterThan
 	}
 
-	if aPre < bPre {
+	if "" == b {
+		return LessThan
+	}
+
+	return compareIdentifiers(strings.Split(a, "."), strings.Split(b, "."))
+}
+
+func compareIdentifiers(a []string, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if d := compareIdentifier(a[i], b[i]); d != Equal {
+			return d
+		}
+	}
+
+	return comparePart(len(a), len(b))
+}
+
+func compareIdentifier(a string, b string) int {
+	aNumeric := isNumericIdentifier(a)
+	bNumeric := isNumericIdentifier(b)
+
+	if aNumeric && bNumeric {
+		// compare the digits without converting them, so identifiers of any length keep their precision
+		a = strings.TrimLeft(a, "0")
+		b = strings.TrimLeft(b, "0")
+
+		if d := comparePart(len(a), len(b)); d != Equal {
+			return d
+		}
+
+		return compareStrings(a, b)
+	}
+
+	if aNumeric {
 		return LessThan
 	}
 
+	if bNumeric {
+		return GreaterThan
+	}
+
+	return compareStrings(a, b)
+}
+
+func isNumericIdentifier(identifier string) bool {
+	if "" == identifier {
+		return false
+	}
+
+	for _, r := range identifier {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func compareParts(a []int, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if d := comparePart(partAt(a, i), partAt(b, i)); d != Equal {
+			return d
+		}
+	}
+
 	return Equal
 }
 
-func compareStability(a string, b string) int {
-	matches := map[string]int{"dev": 1, "alpha": 2, "beta": 3, "RC": 4, "stable": 5, "": 5}
+func partAt(parts []int, i int) int {
+	if i < len(parts) {
+		return parts[i]
+	}
 
-	if matches[a] > matches[b] {
+	return 0
+}
+
+func compareStrings(a string, b string) int {
+	if a > b {
 		return GreaterThan
 	}
 
-	if matches[a] < matches[b] {
+	if a < b {
 		return LessThan
 	}
 
@@ -144,6 +144,47 @@ func TestCompare(t *testing.T) {
 	}
 }
 
+func TestCompareStrictPrecedence(t *testing.T) {
+	// precedence example from the SemVer 2.0.0 specification
+	versions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1-0",
+		"1.0.1-99999999999999999999999",
+		"1.0.1-100000000000000000000000",
+		"1.0.1",
+	}
+
+	for i := 1; i < len(versions); i++ {
+		t.Run(versions[i-1]+" < "+versions[i], func(t *testing.T) {
+			a, _ := NewStrictVersion(versions[i-1])
+			b, _ := NewStrictVersion(versions[i])
+
+			assert.True(t, a.LessThan(b))
+			assert.True(t, b.GreaterThan(a))
+		})
+	}
+
+	a, _ := NewStrictVersion("1.0.0+build.1")
+	b, _ := NewStrictVersion("1.0.0+build.2")
+	assert.True(t, a.Equal(b), "build metadata must be ignored")
+}
+
+func TestCompareStrictWithComposerVersions(t *testing.T) {
+	strict, _ := NewStrictVersion("1.2.3-beta.2")
+	composer, _ := NewVersion("1.2.3-beta3")
+	assert.True(t, strict.LessThan(composer))
+
+	c, _ := NewConstraint("^1.2@beta")
+	assert.True(t, c.Matches(strict))
+}
+
 func BenchmarkCompare(b *testing.B) {
 	for _, tc := range getComparisonCasesForBench() {
 		b.Run(fmt.Sprintf("Compare versions: %s %s %s", tc.versionA.String(), tc.operator, tc.versionB.String()), func(b *testing.B) {
@@ -197,6 +238,36 @@ func getComparisonCases() []struct {
 		{"1.25.0", "<>", "1.24.0", true},
 		{"1.25.0", "<>", "1.25.0", false},
 		{"1.25.0", "<>", "1.26.0", true},
+		{"1.2.3.4", ">", "1.2.3.3", true},
+		{"1.2.3.4", "<", "1.2.3.5", true},
+		{"1.2.3.4", "==", "1.2.3.5", false},
+		{"1.2.3.4", "==", "1.2.3.4", true},
+		{"1.2.3.0", "==", "1.2.3", true},
+		{"1.2.3.10", ">", "1.2.3.9", true},
+		{"1.2.3.1", "<", "1.2.4", true},
+		{"1.2.3.4-beta", "<", "1.2.3.4", true},
+		{"2010-01-02", "<", "2010-01-10", true},
+		{"2010-01-02", "==", "2010-01-10", false},
+		{"2010-01-02", "==", "2010.01.02", true},
+		{"2010-08-09", ">", "2010-07-31", true},
+		{"20100102-203040", "<", "20100102-203041", true},
+		{"20100102-203040-p1", "<", "20100102-203040-p2", true},
+		{"2010-01-02", ">", "1.0.0", true},
+		{"dev-foo", "==", "dev-foo", true},
+		{"dev-foo", "==", "dev-bar", false},
+		{"dev-foo", "!=", "dev-bar", true},
+		{"dev-foo", "!=", "dev-foo", false},
+		{"dev-foo", "==", "0.0.0", false},
+		{"dev-foo", "<", "0.0.0", true},
+		{"dev-master", ">", "dev-foo", true},
+		{"1.0.0-beta.10", ">", "1.0.0-beta.9", true},
+		{"1.0.0-RC1.10", ">", "1.0.0-RC1.2", true},
+		{"1.0.0-beta", "<", "1.0.0-beta1", true},
+		{"1.0.0-beta1", "<", "1.0.0-beta1.0", true},
+		{"1.0.0-beta16777217", ">", "1.0.0-beta16777216", true},
+		{"1.0.0-beta123456789012345678901", ">", "1.0.0-beta123456789012345678900", true},
+		{"1.0.0-beta007", "==", "1.0.0-beta7", true},
+		{"2010-01-02-p2", ">", "2010-01-02-p1", true},
 	}
 	return cases
 }
@@ -1,7 +1,6 @@
 package semver
 
 import (
-	"errors"
 	"fmt"
 	"github.com/spf13/cast"
 	"math"
@@ -10,75 +9,108 @@ import (
 )
 
 type Constraint struct {
-	operator    string
-	version     *Version
-	constraints []*Constraint
-	conjunctive bool
-	isEmpty     bool
+	operator      string
+	version       *Version
+	constraints   []*Constraint
+	conjunctive   bool
+	isEmpty       bool
+	pretty        string
+	stabilityFlag string
+	reference     string
 }
 
 var (
-	versionReg             = `v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?` + stabilityRegex + `?([.-]?dev)?(?:\+[^\s]+)?`
-	operatorMap            = map[string]string{"=": "==", "==": "==", "<>": "!=", "!=": "!=", ">": ">", "<": "<", "<=": "<=", ">=": ">="}
-	stabilityModifierRegex = regexp.MustCompile(`(?i)^([^,\s]*?)@(stable|RC|beta|alpha|dev)$`)
-	simpleComparisonRegex  = regexp.MustCompile(`^(<>|!=|>=?|<=?|==?)?\s*(.*)`)
-	xRangeRegex            = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.[xX*])+$`)
-	tildeRegex             = regexp.MustCompile(`(?i)^~>?` + versionReg + `$`)
-	caretRegex             = regexp.MustCompile(`(?i)^\^` + versionReg + `$`)
-	hyphenRegex            = regexp.MustCompile(`(?i)^(` + versionReg + `) +- +(` + versionReg + `)($)`)
-	devConstraintRegex     = regexp.MustCompile(`(?i)^(dev-[^,\s@]+?|[^,\s@]+?\.x-dev)#.+$`)
-	orSplitRegex           = regexp.MustCompile(`\s*\|\|?\s*`)
-	andConstraintRegex     = regexp.MustCompile(`\s*[ ,]\s*`)
+	operatorMap           = map[string]string{"=": "==", "==": "==", "<>": "!=", "!=": "!=", ">": ">", "<": "<", "<=": "<=", ">=": ">="}
+	simpleComparisonRegex = regexp.MustCompile(`^(<>|!=|>=?|<=?|==?)?\s*(.*)`)
+	xRangeRegex           = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.[xX*])+$`)
+	devConstraintRegex    = regexp.MustCompile(`(?i)^(dev-[^,\s@]+?|[^,\s@]+?\.x-dev)#(.+)$`)
+	orSplitRegex          = regexp.MustCompile(`\s*\|\|?\s*`)
+	andConstraintRegex    = regexp.MustCompile(`\s*[ ,]\s*`)
 )
 
+// compileConstraintRegexes compiles the regexes of the constraints which match a version or a stability flag
+func (r *stabilityRegistry) compileConstraintRegexes() {
+	versionReg := `v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?` + r.stabilityRegex + `?([.-]?dev)?(?:\+[^\s]+)?`
+	flags := r.flagAlternatives()
+
+	r.stabilityModifierRegex = regexp.MustCompile(`(?i)^([^,\s]*?)@(` + flags + `)$`)
+	r.stabilityFlagRegex = regexp.MustCompile(`(?i)^[^@]*?@(` + flags + `)$`)
+	r.tildeRegex = regexp.MustCompile(`(?i)^~>?` + versionReg + `$`)
+	r.caretRegex = regexp.MustCompile(`(?i)^\^` + versionReg + `$`)
+	r.hyphenRegex = regexp.MustCompile(`(?i)^(` + versionReg + `) +- +(` + versionReg + `)($)`)
+}
+
 func NewConstraint(constraint string) (*Constraint, error) {
+	r := currentStabilities()
+	c, err := r.newConstraint(constraint)
+
+	if parseErr, ok := err.(*ConstraintParseError); ok {
+		parseErr.Suggestion = r.suggestConstraint(constraint)
+		return nil, err
+	}
+
+	if nil != err {
+		return nil, err
+	}
+
+	c.pretty = constraint
+	c.stabilityFlag = r.extractStabilityFlag(constraint)
+	c.reference = r.extractReference(constraint)
+
+	return c, nil
+}
+
+func (r *stabilityRegistry) newConstraint(constraint string) (*Constraint, error) {
 	var version = constraint
 
-	result := stabilityModifierRegex.FindStringSubmatch(constraint)
+	result := r.stabilityModifierRegex.FindStringSubmatch(constraint)
 
 	if nil != result {
 		version = result[1]
 	}
 
-	result = devConstraintRegex.FindStringSubmatch(constraint)
+	result = devConstraintRegex.FindStringSubmatch(version)
 
 	if nil != result {
 		version = result[1]
 	}
 
-	orConstraints := orSplitRegex.Split(version, -1)
+	orConstraints, orOffsets := splitOffsets(orSplitRegex, version)
 	var orGroups []*Constraint
 
-	for _, constraints := range orConstraints {
+	for i, constraints := range orConstraints {
 
-		andConstraints := parseAndConstraints(constraints)
+		andConstraints, spans := parseAndConstraints(constraints)
 
 		if len(andConstraints) > 1 {
 			andRange := &Constraint{conjunctive: true}
 
-			for _, constraints := range andConstraints {
-				c, err := parseConstraint(constraints)
+			for j, constraints := range andConstraints {
+				c, err := r.parseConstraint(constraints)
 
 				if nil != err {
-					return nil, err
+					return nil, newConstraintParseError(constraint, orOffsets[i]+spans[j][0], orOffsets[i]+spans[j][1], err)
 				}
 
 				if len(c.constraints) > 0 {
 					andRange.constraints = append(andRange.constraints, c.constraints...)
 				} else {
+					c.pretty = version[orOffsets[i]+spans[j][0] : orOffsets[i]+spans[j][1]]
 					andRange.constraints = append(andRange.constraints, c)
 				}
 			}
 
+			andRange.pretty = constraints
 			orGroups = append(orGroups, andRange)
 
 		} else {
-			c, err := parseConstraint(constraints)
+			c, err := r.parseConstraint(constraints)
 
 			if nil != err {
-				return nil, err
+				return nil, newConstraintParseError(constraint, orOffsets[i], orOffsets[i]+len(constraints), err)
 			}
 
+			c.pretty = constraints
 			orGroups = append(orGroups, c)
 		}
 
@@ -95,14 +127,116 @@ func NewConstraint(constraint string) (*Constraint, error) {
 		"<" == orGroups[0].constraints[1].operator &&
 		">=" == orGroups[1].constraints[0].operator &&
 		"<" == orGroups[1].constraints[1].operator &&
-		orGroups[0].constraints[1].version.String() == orGroups[1].constraints[0].version.String() {
+		orGroups[0].constraints[1].version.String() == orGroups[1].constraints[0].version.String() &&
+		// only collapse groups which are in order and match at least one version, otherwise versions are lost
+		orGroups[0].IsSatisfiable() && orGroups[1].IsSatisfiable() &&
+		orGroups[0].constraints[0].version.LessThanOrEqual(orGroups[1].constraints[0].version) &&
+		orGroups[1].constraints[0].version.LessThanOrEqual(orGroups[1].constraints[1].version) {
 
-		return &Constraint{constraints: []*Constraint{orGroups[0].constraints[0], orGroups[1].constraints[1]}, conjunctive: false}, nil
+		return &Constraint{constraints: []*Constraint{orGroups[0].constraints[0], orGroups[1].constraints[1]}, conjunctive: true}, nil
 	}
 
 	return &Constraint{conjunctive: false, constraints: orGroups}, nil
 }
 
+/*
+ Stability Flags
+
+ Returns the most unstable stability flag of a constraint like Composer's RootPackageLoader. Explicit flags
+ like "1.0@beta" take precedence, otherwise the flag is inferred from unstable versions in the constraint,
+ like "1.0-beta2" or "dev-master". An empty string is returned if the constraint only allows stable versions.
+*/
+func (r *stabilityRegistry) extractStabilityFlag(constraint string) string {
+	var parts []string
+
+	for _, group := range orSplitRegex.Split(strings.TrimSpace(constraint), -1) {
+		and, _ := parseAndConstraints(group)
+		parts = append(parts, and...)
+	}
+
+	explicit, inferred := "", ""
+
+	for _, part := range parts {
+		if match := r.stabilityFlagRegex.FindStringSubmatch(part); nil != match {
+			explicit = r.lessStable(explicit, r.expandStability(strings.ToLower(match[1])))
+			continue
+		}
+
+		// like Composer, flags are only inferred from single versions, not from hyphen ranges
+		part = aliasRegex.ReplaceAllString(part, "$1")
+		if strings.ContainsAny(part, " \t") {
+			continue
+		}
+
+		if stability := r.parseStability(part); "stable" != stability && "" != stability {
+			inferred = r.lessStable(inferred, stability)
+		}
+	}
+
+	if "" != explicit {
+		return explicit
+	}
+
+	return inferred
+}
+
+// extractReference returns the source reference of a dev constraint, "abcd123" for "1.0.x-dev#abcd123"
+func (r *stabilityRegistry) extractReference(constraint string) string {
+	if match := r.stabilityModifierRegex.FindStringSubmatch(constraint); nil != match {
+		constraint = match[1]
+	}
+
+	if match := devConstraintRegex.FindStringSubmatch(constraint); nil != match {
+		return match[2]
+	}
+
+	return ""
+}
+
+// lessStable returns the more unstable of two stabilities, an empty stability means there is none yet
+func (r *stabilityRegistry) lessStable(a string, b string) string {
+	if "" == a || r.rank(Stability(b)) < r.rank(Stability(a)) {
+		return b
+	}
+
+	return a
+}
+
+// NewSatisfiableConstraint is the strict mode of NewConstraint, it returns an *UnsatisfiableConstraintError
+// if one of the AND groups of the constraint can never match any version
+func NewSatisfiableConstraint(constraint string) (*Constraint, error) {
+	c, err := NewConstraint(constraint)
+
+	if nil != err {
+		return nil, err
+	}
+
+	groups := []*Constraint{c}
+	if len(c.constraints) > 0 && !c.conjunctive {
+		groups = c.constraints
+	}
+
+	for _, group := range groups {
+		if !group.IsSatisfiable() {
+			return nil, &UnsatisfiableConstraintError{Constraint: constraint, Group: group.PrettyString()}
+		}
+	}
+
+	return c, nil
+}
+
+// StabilityFlag returns the most unstable stability flag of the constraint, e.g. "beta" for "1.0@beta" or
+// "dev" for "dev-master", so the minimum stability can be lowered for it like Composer does
+func (c *Constraint) StabilityFlag() string {
+	return c.stabilityFlag
+}
+
+// Reference returns the source reference of a dev constraint, e.g. the commit "abcd123" of
+// "1.0.x-dev#abcd123", or an empty string if the constraint has no reference
+func (c *Constraint) Reference() string {
+	return c.reference
+}
+
 func (c *Constraint) Matches(version *Version) bool {
 	if len(c.constraints) > 0 {
 		if false == c.conjunctive {
@@ -129,14 +263,78 @@ func (c *Constraint) Matches(version *Version) bool {
 		return true
 	}
 
+	if nil == c.version {
+		return false
+	}
+
+	// like Composer's matchSpecific, branches are only compared by name and > and < can never match a branch
+	if version.isBranch || c.version.isBranch {
+		switch c.operator {
+		case "==", "=", "!=", "<>":
+			return version.Compare(c.version, c.operator)
+		}
+
+		return false
+	}
+
 	return version.Compare(c.version, c.operator)
 }
 
+// Intersect returns a simplified constraint which only matches versions matched by both constraints
+func (c *Constraint) Intersect(other *Constraint) *Constraint {
+	return compactConstraint(&Constraint{constraints: []*Constraint{c, other}, conjunctive: true})
+}
+
+// Union returns a simplified constraint which matches versions matched by either constraint
+func (c *Constraint) Union(other *Constraint) *Constraint {
+	return compactConstraint(&Constraint{constraints: []*Constraint{c, other}, conjunctive: false})
+}
+
+// IsSubsetOf checks if every version matched by the constraint is also matched by the other constraint
+func (c *Constraint) IsSubsetOf(other *Constraint) bool {
+	if other.isEmpty {
+		return true
+	}
+
+	intersection := generateIntervals(&Constraint{constraints: []*Constraint{c, other}, conjunctive: true})
+
+	return intersection.Equal(generateIntervals(c))
+}
+
+// IsSupersetOf checks if every version matched by the other constraint is also matched by the constraint
+func (c *Constraint) IsSupersetOf(other *Constraint) bool {
+	return other.IsSubsetOf(c)
+}
+
+// IsSatisfiable checks if there is at least one version which matches the constraint
+func (c *Constraint) IsSatisfiable() bool {
+	return generateIntervals(c).isSatisfiable()
+}
+
+// PrettyString returns the constraint as it was written, e.g. "^1.2" for a constraint parsed from "^1.2". Every
+// node keeps the part of the input it was parsed from, nodes which weren't parsed from the input, like the
+// bounds of "^1.2" or the result of Intersect, are formatted as a constraint which parses to the same node
+func (c *Constraint) PrettyString() string {
+	if "" != c.pretty {
+		return c.pretty
+	}
+
+	if pretty, err := c.format(); nil == err {
+		return pretty
+	}
+
+	return c.String()
+}
+
 func (c *Constraint) String() string {
 	if c.isEmpty {
 		return "[]"
 	}
 
+	if 0 == len(c.constraints) && nil == c.version {
+		return "[none]"
+	}
+
 	if 0 == len(c.constraints) {
 		result := fmt.Sprintf("%s %s", c.operator, c.version.String())
 		if "-stable" == result[len(result)-7:] {
@@ -173,28 +371,41 @@ func (c *Constraint) String() string {
 	return "[" + strings.Join(constraints, glue) + "]"
 }
 
-func parseAndConstraints(constraint string) []string {
+// parseAndConstraints splits an AND group into its constraints, joining operators and hyphen ranges written
+// with spaces. It also returns the start and end offset of each constraint within the group
+func parseAndConstraints(constraint string) ([]string, [][2]int) {
 	var (
 		index          = 0
 		constraintPart = 0
 		constraints    []string
+		spans          [][2]int
 	)
 
-	split := andConstraintRegex.Split(constraint, -1)
+	split, offsets := splitOffsets(andConstraintRegex, constraint)
 
 	if 1 == len(split) {
-		return []string{constraint}
+		return []string{constraint}, [][2]int{{0, len(constraint)}}
 	}
 
-	var parts []string
-	for _, str := range split {
+	var (
+		parts       []string
+		partOffsets []int
+	)
+	for i, str := range split {
 		if str != "" {
 			parts = append(parts, str)
+			partOffsets = append(partOffsets, offsets[i])
 		}
 	}
 
 	partsLen := len(parts)
 
+	// appendPart adds the current part to the current constraint and moves the end of its span
+	appendPart := func(glue string) {
+		constraints[constraintPart] += glue + parts[index]
+		spans[constraintPart][1] = partOffsets[index] + len(parts[index])
+	}
+
 	for {
 
 		if index >= partsLen {
@@ -202,9 +413,11 @@ func parseAndConstraints(constraint string) []string {
 		}
 
 		constraints = append(constraints, "")
+		spans = append(spans, [2]int{partOffsets[index], partOffsets[index]})
 
-		if "<" == parts[index] || ">" == parts[index] || ">=" == parts[index] || "<=" == parts[index] || "^" == parts[index] || "!=" == parts[index] {
-			constraints[constraintPart] += parts[index]
+		if "<" == parts[index] || ">" == parts[index] || ">=" == parts[index] || "<=" == parts[index] || "^" == parts[index] ||
+			"!=" == parts[index] || "<>" == parts[index] || "==" == parts[index] || "=" == parts[index] {
+			appendPart("")
 			index++
 
 			if index >= partsLen {
@@ -212,7 +425,7 @@ func parseAndConstraints(constraint string) []string {
 			}
 		}
 
-		constraints[constraintPart] += parts[index]
+		appendPart("")
 
 		if index+1 >= partsLen {
 			break
@@ -220,10 +433,10 @@ func parseAndConstraints(constraint string) []string {
 
 		if "as" == parts[index+1] || "-" == parts[index+1] {
 			index++
-			constraints[constraintPart] += " " + parts[index]
+			appendPart(" ")
 
 			index++
-			constraints[constraintPart] += " " + parts[index]
+			appendPart(" ")
 
 		}
 
@@ -231,41 +444,58 @@ func parseAndConstraints(constraint string) []string {
 		constraintPart++
 	}
 
-	return constraints
+	return constraints, spans
+}
+
+// splitOffsets splits s like re.Split(s, -1) and also returns the byte offset of each part within s
+func splitOffsets(re *regexp.Regexp, s string) ([]string, []int) {
+	var (
+		parts   []string
+		offsets []int
+		start   = 0
+	)
+
+	for _, match := range re.FindAllStringIndex(s, -1) {
+		parts = append(parts, s[start:match[0]])
+		offsets = append(offsets, start)
+		start = match[1]
+	}
+
+	return append(parts, s[start:]), append(offsets, start)
 }
 
-func parseConstraint(constraint string) (*Constraint, error) {
+func (r *stabilityRegistry) parseConstraint(constraint string) (*Constraint, error) {
 	b := []byte(constraint)
 
 	if match, _ := regexp.Match(`^v?[xX*](\.[xX*])*$`, b); match {
 		return &Constraint{isEmpty: true}, nil
 	}
 
-	if tildeRegex.Match(b) {
-		return parseTilde(constraint)
+	if r.tildeRegex.Match(b) {
+		return r.parseTilde(constraint)
 	}
 
-	if caretRegex.Match(b) {
-		return caretRange(constraint)
+	if r.caretRegex.Match(b) {
+		return r.caretRange(constraint)
 	}
 
 	if xRangeRegex.Match(b) {
-		return xRange(constraint)
+		return r.xRange(constraint)
 	}
 
-	if hyphenRegex.Match(b) {
-		return hyphenRange(constraint)
+	if r.hyphenRegex.Match(b) {
+		return r.hyphenRange(constraint)
 	}
 
 	if simpleComparisonRegex.Match(b) {
-		return basicRange(constraint)
+		return r.basicRange(constraint)
 	}
 
-	return nil, fmt.Errorf("unable to parse constraint %s", constraint)
+	return nil, &ConstraintParseError{Constraint: constraint, Reason: ReasonInvalidConstraint}
 }
 
-func basicRange(constraint string) (*Constraint, error) {
-	result := stabilityModifierRegex.FindStringSubmatch(constraint)
+func (r *stabilityRegistry) basicRange(constraint string) (*Constraint, error) {
+	result := r.stabilityModifierRegex.FindStringSubmatch(constraint)
 
 	var stability = ""
 
@@ -280,17 +510,17 @@ func basicRange(constraint string) (*Constraint, error) {
 
 	version := matches[2]
 
-	if "" != stability && "stable" == ParseStability(version) {
+	if "" != stability && "stable" == r.parseStability(version) {
 		version += "-" + stability
 	} else if "<" == matches[1] || ">=" == matches[1] {
-		if !stabilityRegexC.Match([]byte(version)) {
+		if !r.stabilityRegexC.Match([]byte(version)) {
 			if len(version) < 4 || "dev-" != version[0:4] {
 				version += "-dev"
 			}
 		}
 	}
 
-	v, err := NewVersion(version)
+	v, err := r.newVersion(version)
 
 	if nil != err {
 		return nil, err
@@ -313,8 +543,8 @@ func basicRange(constraint string) (*Constraint, error) {
  the inclusive range, then all versions that start with the supplied parts of the tuple are accepted, but
  nothing that would be greater than the provided tuple parts.
 */
-func hyphenRange(constraint string) (*Constraint, error) {
-	matches := hyphenRegex.FindStringSubmatch(constraint)
+func (r *stabilityRegistry) hyphenRange(constraint string) (*Constraint, error) {
+	matches := r.hyphenRegex.FindStringSubmatch(constraint)
 
 	c := &Constraint{conjunctive: true}
 
@@ -323,7 +553,7 @@ func hyphenRange(constraint string) (*Constraint, error) {
 	if "" == matches[6] && "" == matches[8] {
 		lowStabilitySuffix = "-dev"
 	}
-	lowVersion, err := NewVersion(matches[1] + lowStabilitySuffix)
+	lowVersion, err := r.newVersion(matches[1] + lowStabilitySuffix)
 
 	if nil != err {
 		return nil, err
@@ -339,7 +569,7 @@ func hyphenRange(constraint string) (*Constraint, error) {
 	}
 
 	if (!isEmpty(matches[11]) && !isEmpty(matches[12])) || "" != matches[14] || "" != matches[16] {
-		highVersion, err := NewVersion(matches[9])
+		highVersion, err := r.newVersion(matches[9])
 		if nil != err {
 			return nil, err
 		}
@@ -353,7 +583,7 @@ func hyphenRange(constraint string) (*Constraint, error) {
 		} else {
 			position = 2
 		}
-		highVersion, err := expandVersion(highMatch, position, 1, "0", "-dev")
+		highVersion, err := r.expandVersion(highMatch, position, 1, "0", "-dev")
 
 		if nil != err {
 			return nil, err
@@ -371,7 +601,7 @@ func hyphenRange(constraint string) (*Constraint, error) {
  Any of X, x, or * may be used to "stand in" for one of the numeric values in the [major, minor, patch] tuple.
  A partial version range is treated as an X-Range, so the special character is in fact optional.
 */
-func xRange(constraint string) (*Constraint, error) {
+func (r *stabilityRegistry) xRange(constraint string) (*Constraint, error) {
 	matches := xRangeRegex.FindStringSubmatch(constraint)
 	position := 0
 
@@ -382,13 +612,13 @@ func xRange(constraint string) (*Constraint, error) {
 		}
 	}
 
-	lowVersion, err := expandVersion(matches, position, 0, "0", "-dev")
+	lowVersion, err := r.expandVersion(matches, position, 0, "0", "-dev")
 
 	if nil != err {
 		return nil, err
 	}
 
-	highVersion, err := expandVersion(matches, position, 1, "0", "-dev")
+	highVersion, err := r.expandVersion(matches, position, 1, "0", "-dev")
 
 	if nil != err {
 		return nil, err
@@ -408,8 +638,8 @@ func xRange(constraint string) (*Constraint, error) {
  In other words, this allows patch and minor updates for versions 1.0.0 and above, patch updates for
  versions 0.X >=0.1.0, and no updates for versions 0.0.X
 */
-func caretRange(constraint string) (*Constraint, error) {
-	matches := caretRegex.FindStringSubmatch(constraint)
+func (r *stabilityRegistry) caretRange(constraint string) (*Constraint, error) {
+	matches := r.caretRegex.FindStringSubmatch(constraint)
 	stabilitySuffix := ""
 	position := 0
 
@@ -425,14 +655,14 @@ func caretRange(constraint string) (*Constraint, error) {
 		stabilitySuffix = "-dev"
 	}
 
-	lowVersion, err := NewVersion(constraint[1:] + stabilitySuffix)
+	lowVersion, err := r.newVersion(constraint[1:] + stabilitySuffix)
 
 	if nil != err {
 		return nil, err
 	}
 	// For upper bound, we increment the position of one more significance,
 	// but highPosition = 0 would be illegal
-	highVersion, err := expandVersion(matches, position, 1, "0", "-dev")
+	highVersion, err := r.expandVersion(matches, position, 1, "0", "-dev")
 
 	if nil != err {
 		return nil, err
@@ -448,12 +678,11 @@ func caretRange(constraint string) (*Constraint, error) {
  version, to ensure that unstable instances of the current version are allowed. However, if a stability
  suffix is added to the constraint, then a >= match on the current version is used instead.
 */
-func parseTilde(constraint string) (*Constraint, error) {
-	matches := tildeRegex.FindStringSubmatch(constraint)
+func (r *stabilityRegistry) parseTilde(constraint string) (*Constraint, error) {
+	matches := r.tildeRegex.FindStringSubmatch(constraint)
 
 	if "~>" == constraint[0:2] {
-		return nil, errors.New(fmt.Sprintf(`Could not parse version constraint %s: `+
-			`Invalid operator "~>", you probably meant to use the "~" operator`, constraint))
+		return nil, &ConstraintParseError{Constraint: constraint, Reason: ReasonInvalidOperator}
 	}
 	position := 0
 
@@ -466,14 +695,14 @@ func parseTilde(constraint string) (*Constraint, error) {
 
 	stabilitySuffix := ""
 	if "" != matches[5] {
-		stabilitySuffix = "-" + expandStability(matches[5]) + matches[6]
+		stabilitySuffix = "-" + r.expandStability(matches[5]) + matches[6]
 	}
 
 	if "" != matches[7] || "" == stabilitySuffix {
 		stabilitySuffix = "-dev"
 	}
 
-	lowVersion, err := expandVersion(matches, position, 0, "0", stabilitySuffix)
+	lowVersion, err := r.expandVersion(matches, position, 0, "0", stabilitySuffix)
 
 	if nil != err {
 		return nil, err
@@ -482,7 +711,7 @@ func parseTilde(constraint string) (*Constraint, error) {
 	// For upper bound, we increment the position of one more significance,
 	// but highPosition = 0 would be illegal
 	highPosition := math.Max(1, cast.ToFloat64(position-1))
-	highVersion, err := expandVersion(matches, cast.ToInt(highPosition), 1, "0", "-dev")
+	highVersion, err := r.expandVersion(matches, cast.ToInt(highPosition), 1, "0", "-dev")
 
 	if nil != err {
 		return nil, err
@@ -491,7 +720,7 @@ func parseTilde(constraint string) (*Constraint, error) {
 	return &Constraint{constraints: []*Constraint{{operator: ">=", version: lowVersion, conjunctive: true}, {operator: "<", version: highVersion, conjunctive: true}}, conjunctive: true}, nil
 }
 
-func expandVersion(matches []string, position int, increment int, pad string, append string) (*Version, error) {
+func (r *stabilityRegistry) expandVersion(matches []string, position int, increment int, pad string, append string) (*Version, error) {
 	var (
 		i      = 4
 		result = make([]interface{}, 5, 5)
@@ -501,13 +730,13 @@ func expandVersion(matches []string, position int, increment int, pad string, ap
 		if i > position {
 			result[i-1] = pad
 		} else if i == position && increment > 0 {
-			currentValue := cast.ToInt(matches[i])
+			currentValue := parseVersionNumber(matches[i])
 			result[i-1] = cast.ToString(currentValue + increment)
 			if currentValue < 0 {
 				result[i-1] = pad
 				position--
 				if i == 1 {
-					return nil, fmt.Errorf("carry overflow error")
+					return nil, &ConstraintParseError{Reason: ReasonNumberOverflow, Err: fmt.Errorf("carry overflow error")}
 				}
 			}
 		} else {
@@ -518,7 +747,7 @@ func expandVersion(matches []string, position int, increment int, pad string, ap
 
 	result[4] = append
 
-	version, err := NewVersion(fmt.Sprintf("%s.%s.%s.%s%s", result...))
+	version, err := r.newVersion(fmt.Sprintf("%s.%s.%s.%s%s", result...))
 
 	if nil != err {
 		return nil, err
@@ -26,6 +26,30 @@ func TestParseConstraintsIgnoresReferenceOnDevVersion(t *testing.T) {
 	}
 }
 
+func TestConstraintReference(t *testing.T) {
+	cases := []struct {
+		constraint string
+		reference  string
+	}{
+		{"1.0.x-dev#abcd123", "abcd123"},
+		{"1.0.x-dev#trunk/@123", "trunk/@123"},
+		{"dev-master#2eb0c09", "2eb0c09"},
+		{"dev-master#2eb0c09@dev", "2eb0c09"},
+		{"dev-master", ""},
+		{"^1.0", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			constraint, err := NewConstraint(tc.constraint)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.reference, constraint.Reference())
+			}
+		})
+	}
+}
+
 func TestParseConstraintsFailsOnBadReference(t *testing.T) {
 	_, err := NewConstraint("1.0#abcd123")
 
@@ -41,6 +65,122 @@ func TestParseConstraintsNudgesRubyDevsTowardsThePathOfRighteousness(t *testing.
 	assert.EqualError(t, err, "Could not parse version constraint ~>1.2: Invalid operator \"~>\", you probably meant to use the \"~\" operator")
 }
 
+func TestConstraintParseError(t *testing.T) {
+	cases := []struct {
+		constraint string
+		token      string
+		offset     int
+		reason     ParseErrorReason
+	}{
+		{"1.0.0-meh", "1.0.0-meh", 0, ReasonInvalidVersion},
+		{"^1.0 || >= 2.0 foo", "foo", 15, ReasonInvalidVersion},
+		{">=1.0,  <= 2.0-meh", "<= 2.0-meh", 8, ReasonInvalidVersion},
+		{"^1.0 || ~>1.2", "~>1.2", 8, ReasonInvalidOperator},
+		{"1.0 - 2.0 1.x-meh || 3.0", "1.x-meh", 10, ReasonInvalidVersion},
+		{"dev-foo ||  bar baz", "bar", 12, ReasonInvalidVersion},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			_, err := NewConstraint(tc.constraint)
+
+			parseErr, ok := err.(*ConstraintParseError)
+			if !assert.True(t, ok, "expected a *ConstraintParseError, got %T", err) {
+				return
+			}
+
+			assert.Equal(t, tc.constraint, parseErr.Input)
+			assert.Equal(t, tc.token, parseErr.Constraint)
+			assert.Equal(t, tc.offset, parseErr.Offset)
+			assert.Equal(t, tc.token, parseErr.Input[parseErr.Offset:parseErr.Offset+len(parseErr.Constraint)])
+			assert.Equal(t, tc.reason, parseErr.Reason)
+		})
+	}
+}
+
+func TestConstraintParseErrorWrapsVersionError(t *testing.T) {
+	_, err := NewConstraint("^1.0 || 1.0.0-meh")
+
+	assert.EqualError(t, err, "unable to parse constraint 1.0.0-meh: unable to parse version 1.0.0-meh")
+
+	parseErr, ok := err.(*ConstraintParseError)
+	if assert.True(t, ok) {
+		versionErr, ok := parseErr.Unwrap().(*VersionParseError)
+		if assert.True(t, ok, "expected a *VersionParseError, got %T", parseErr.Unwrap()) {
+			assert.Equal(t, "1.0.0-meh", versionErr.Version)
+		}
+	}
+}
+
+func TestConstraintPrettyString(t *testing.T) {
+	constraint, err := NewConstraint("^1.2 ||  >=2.0, <2.5 || dev-foo")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "^1.2 ||  >=2.0, <2.5 || dev-foo", constraint.PrettyString())
+
+	var pretty []string
+	constraint.Walk(func(c *Constraint) bool {
+		pretty = append(pretty, c.PrettyString())
+		return true
+	})
+
+	assert.Equal(t, []string{
+		"^1.2 ||  >=2.0, <2.5 || dev-foo",
+		"^1.2", ">=1.2.0.0-dev", "<2.0.0.0-dev",
+		">=2.0, <2.5", ">=2.0", "<2.5",
+		"dev-foo",
+	}, pretty)
+}
+
+func TestConstraintPrettyStringOfBuiltConstraints(t *testing.T) {
+	a, _ := NewConstraint("^1.2")
+	b, _ := NewConstraint("<1.5")
+
+	intersection := a.Intersect(b)
+
+	assert.Equal(t, ">=1.2.0.0-dev <1.5.0.0-dev", intersection.PrettyString())
+
+	parsed, err := NewConstraint(intersection.PrettyString())
+	if assert.NoError(t, err) {
+		assert.Equal(t, intersection.String(), parsed.String())
+	}
+}
+
+func TestConstraintStabilityFlag(t *testing.T) {
+	cases := []struct {
+		constraint string
+		flag       string
+	}{
+		{"^1.0", ""},
+		{"1.0@beta", "beta"},
+		{"^1.0@RC", "RC"},
+		{"1.0@stable", "stable"},
+		{">=1.0@Alpha", "alpha"},
+		{"1.0@beta || 2.0@dev", "dev"},
+		{">2.0@stable,<=3.0@dev", "dev"},
+		{"dev-master", "dev"},
+		{"1.0.x-dev", "dev"},
+		{"dev-master#abc123", "dev"},
+		{"1.0.0-beta2", "beta"},
+		{"1.0.0-beta2 || 1.0.0-RC1", "beta"},
+		{"1.0.0-beta2 || 2.0@RC", "RC"},
+		{"dev-master as 1.0.0", "dev"},
+		{"1.0 - 2.0-beta", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			constraint, err := NewConstraint(tc.constraint)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.flag, constraint.StabilityFlag())
+			}
+		})
+	}
+}
+
 func TestParseConstraintsSimple(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -237,7 +377,36 @@ func TestParseConstraintsMulti(t *testing.T) {
 func TestParseConstraintsMultiCollapsesContiguous(t *testing.T) {
 	constraint, err := NewConstraint("^2.5 || ^3.0")
 	if assert.NoError(t, err) {
-		assert.Equal(t, "[>= 2.5.0.0-dev || < 4.0.0.0-dev]", constraint.String())
+		assert.Equal(t, "[>= 2.5.0.0-dev < 4.0.0.0-dev]", constraint.String())
+
+		version, _ := NewVersion("5.0.0")
+		assert.False(t, constraint.Matches(version))
+	}
+}
+
+func TestParseConstraintsMultiKeepsUnorderedGroups(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+	}{
+		{">=2.0 <1.0 || ^1.0", "1.5.0"},
+		{">=1.0 <3.0 || >=3.0 <2.0", "2.5.0"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			constraint, err := NewConstraint(tc.constraint)
+			if assert.NoError(t, err) {
+				version, _ := NewVersion(tc.version)
+				assert.True(t, constraint.Matches(version))
+				assert.True(t, constraint.Intervals().Matches(version))
+			}
+		})
+	}
+
+	_, err := NewSatisfiableConstraint(">=2.0 <1.0 || ^1.0")
+	if assert.IsType(t, &UnsatisfiableConstraintError{}, err) {
+		assert.Equal(t, ">=2.0 <1.0", err.(*UnsatisfiableConstraintError).Group)
 	}
 }
 
@@ -385,7 +554,7 @@ func TestConstraintMatches(t *testing.T) {
 		{"~2.4", "2.4.5", true},
 		{"~1", "1.2.3", true},   //  >=1.0.0 <2.0.0
 		{"~1.0", "1.4.7", true}, // >=1.0.0 <2.0.0
-		{">=1'),", "1.0.0", true},
+		{">=1'),", "1.0.0", false}, // >= dev-1'), can never match
 		{">= 1", "1.0.0", true},
 		{">1.2", "1.2.8", true}, // >1.2.0
 		{"<1.2", "1.1.1", true},
@@ -469,6 +638,260 @@ func TestConstraintMatches(t *testing.T) {
 
 }
 
+func TestConstraintIntersect(t *testing.T) {
+	cases := []struct {
+		constraintA string
+		constraintB string
+		result      string
+	}{
+		{"^1.2", "<1.5", "[>= 1.2.0.0-dev < 1.5.0.0-dev]"},
+		{"^1.0", "^2.0", "[none]"},
+		{"^1.0 || ^3.0", "^1.5 || ~3.1", "[[>= 1.5.0.0-dev < 2.0.0.0-dev] || [>= 3.1.0.0-dev < 4.0.0.0-dev]]"},
+		{">=1.0", "!=1.5", "[>= 1.0.0.0-dev != 1.5.0.0]"},
+		{"*", "^1.2", "[>= 1.2.0.0-dev < 2.0.0.0-dev]"},
+		{"*", "*", "[]"},
+		{"1.0.0 || 2.0.0", ">=1.5", "== 2.0.0.0"},
+		{">=2.0", "<1.0", "[none]"},
+		{"dev-foo || ^1.0", "dev-foo", "== dev-foo"},
+		{"dev-foo", "dev-bar", "[none]"},
+		{">=1.0", "!=dev-foo", ">= 1.0.0.0-dev"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraintA+" && "+tc.constraintB, func(t *testing.T) {
+			a, err := NewConstraint(tc.constraintA)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			b, err := NewConstraint(tc.constraintB)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.result, a.Intersect(b).String())
+			}
+		})
+	}
+}
+
+func TestConstraintUnion(t *testing.T) {
+	cases := []struct {
+		constraintA string
+		constraintB string
+		result      string
+	}{
+		{"^1.0", "^2.0", "[>= 1.0.0.0-dev < 3.0.0.0-dev]"},
+		{"^1.0", "^1.5", "[>= 1.0.0.0-dev < 2.0.0.0-dev]"},
+		{"^1.0", "^3.0", "[[>= 1.0.0.0-dev < 2.0.0.0-dev] || [>= 3.0.0.0-dev < 4.0.0.0-dev]]"},
+		{"<1.5-stable", ">1.5", "!= 1.5.0.0"},
+		{">=1.0 <1.5-stable", ">1.5 <2.0", "[>= 1.0.0.0-dev != 1.5.0.0 < 2.0.0.0-dev]"},
+		{"<2.0", ">=1.0", ">= 0.0.0.0-dev"},
+		{"*", "^1.0", "[]"},
+		{"1.0.0", "1.0.0", "== 1.0.0.0"},
+		{"^1.0", "dev-foo", "[[>= 1.0.0.0-dev < 2.0.0.0-dev] || == dev-foo]"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraintA+" || "+tc.constraintB, func(t *testing.T) {
+			a, err := NewConstraint(tc.constraintA)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			b, err := NewConstraint(tc.constraintB)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.result, a.Union(b).String())
+			}
+		})
+	}
+}
+
+func TestConstraintIntersectMatchesBothConstraints(t *testing.T) {
+	a, _ := NewConstraint("^1.0 || ^3.0")
+	b, _ := NewConstraint(">=1.5 !=3.2.0")
+	intersection := a.Intersect(b)
+
+	for _, v := range []string{"1.0.0", "1.5.0", "1.9.9", "2.5.0", "3.0.0", "3.2.0", "3.2.1", "4.0.0"} {
+		version, _ := NewVersion(v)
+		assert.Equal(t, a.Matches(version) && b.Matches(version), intersection.Matches(version), v)
+	}
+}
+
+func TestConstraintIsSubsetOf(t *testing.T) {
+	cases := []struct {
+		candidate  string
+		constraint string
+		subset     bool
+	}{
+		{"^1.2", "^1.0", true},
+		{"^1.0", "^1.2", false},
+		{"~1.2.3", "^1.2", true},
+		{"~1.2", "~1.2.3", false},
+		{"1.2.*", "^1.0", true},
+		{"1.*", "1.2.*", false},
+		{"1.2 - 1.4", "^1.0", true},
+		{"1.2 - 2.4", "^1.0", false},
+		{"1.2.3", ">=1.0 <2.0", true},
+		{">=1.0", ">=0.9", true},
+		{">=1.0", "<2.0", false},
+		{"^1.2 || ^2.0", ">=1.0 <3.0", true},
+		{"^1.2 || ^3.0", ">=1.0 <3.0", false},
+		{"^1.2", "*", true},
+		{"*", "^1.2", false},
+		{"dev-foo", "dev-foo || ^1.0", true},
+		{"dev-foo", "^1.0", false},
+		{">=2.0 <1.0", "^1.0", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.candidate+" subset of "+tc.constraint, func(t *testing.T) {
+			candidate, err := NewConstraint(tc.candidate)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			constraint, err := NewConstraint(tc.constraint)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.subset, candidate.IsSubsetOf(constraint))
+				assert.Equal(t, tc.subset, constraint.IsSupersetOf(candidate))
+			}
+		})
+	}
+}
+
+func TestConstraintIsSatisfiable(t *testing.T) {
+	cases := []struct {
+		constraint  string
+		satisfiable bool
+	}{
+		{"^1.2", true},
+		{"*", true},
+		{">=1.0 <1.0", false},
+		{">=2.0 <1.0", false},
+		{">1.0 <=1.0", false},
+		{">=1.0 <=1.0", true},
+		{"^1.0 ^2.0", false},
+		{">=2.0 <1.0 || ^3.0", true},
+		{"1.0.0 != 1.0.0", false},
+		{"dev-foo", true},
+		{"dev-foo != dev-foo", false},
+		{"!=dev-foo", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			c, err := NewConstraint(tc.constraint)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.satisfiable, c.IsSatisfiable())
+			}
+		})
+	}
+}
+
+func TestNewSatisfiableConstraint(t *testing.T) {
+	c, err := NewSatisfiableConstraint("^1.2 || ^2.0")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "[>= 1.2.0.0-dev < 3.0.0.0-dev]", c.String())
+	}
+
+	_, err = NewSatisfiableConstraint(">=2.0 <1.0")
+	if assert.IsType(t, &UnsatisfiableConstraintError{}, err) {
+		assert.Equal(t, ">=2.0 <1.0", err.(*UnsatisfiableConstraintError).Group)
+		assert.EqualError(t, err, "constraint >=2.0 <1.0 can never be satisfied: >=2.0 <1.0 does not match any version")
+	}
+
+	_, err = NewSatisfiableConstraint("^3.0 || >=2.0 <1.0")
+	if assert.IsType(t, &UnsatisfiableConstraintError{}, err) {
+		assert.Equal(t, ">=2.0 <1.0", err.(*UnsatisfiableConstraintError).Group)
+	}
+
+	_, err = NewSatisfiableConstraint(">=2.0 <1.0 ||")
+	assert.Error(t, err)
+}
+
+func TestConstraintMatchesFourSegments(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		matches    bool
+	}{
+		{"~1.2.3.4", "1.2.3.4", true},
+		{"~1.2.3.4", "1.2.3.5", true},
+		{"~1.2.3.4", "1.2.3.3", false},
+		{"~1.2.3.4", "1.2.4.0", false},
+		{"^1.2.3.4", "1.2.3.3", false},
+		{"^1.2.3.4", "1.9.0.0", true},
+		{"1.2.3.4 - 1.2.3.8", "1.2.3.8", true},
+		{"1.2.3.4 - 1.2.3.8", "1.2.3.9", false},
+		{"1.2.3.4 - 1.2.3.8", "1.2.3.3", false},
+		{"1.2.3.*", "1.2.3.7", true},
+		{"1.2.3.*", "1.2.4.0", false},
+		{"<1.2.3.4", "1.2.3.3", true},
+		{"<1.2.3.4", "1.2.3.4", false},
+		{">1.2.3.4", "1.2.3.5", true},
+		{"1.2.3.4", "1.2.3.5", false},
+		{"!=1.2.3.4", "1.2.3.5", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint+" "+tc.version, func(t *testing.T) {
+			c, err := NewConstraint(tc.constraint)
+			if assert.NoError(t, err) {
+				version, err := NewVersion(tc.version)
+				if assert.NoError(t, err) {
+					assert.Equal(t, tc.matches, c.Matches(version))
+				}
+			}
+		})
+	}
+}
+
+func TestConstraintIntersectFourSegments(t *testing.T) {
+	a, _ := NewConstraint("~1.2.3.4")
+	b, _ := NewConstraint("<1.2.3.8")
+
+	assert.Equal(t, "[>= 1.2.3.4-dev < 1.2.3.8-dev]", a.Intersect(b).String())
+	assert.True(t, a.Intersect(b).IsSubsetOf(a))
+	assert.False(t, a.IsSubsetOf(b))
+}
+
+func TestConstraintMatchesBranches(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		matches    bool
+	}{
+		{"dev-feature-a", "dev-feature-a", true},
+		{"dev-feature-a", "dev-feature-b", false},
+		{"dev-feature-a", "1.0.0", false},
+		{"== dev-feature-a", "dev-feature-a", true},
+		{"!= dev-feature-a", "dev-feature-a", false},
+		{"!= dev-feature-a", "dev-feature-b", true},
+		{"!= dev-feature-a", "1.0.0", true},
+		{"^1.0", "dev-feature-a", false},
+		{"dev-feature-a || ^1.0", "dev-feature-a", true},
+		{"dev-feature-a || ^1.0", "1.2.0", true},
+		{"<1.0", "dev-feature-a", false},
+		{"<=2.0", "dev-feature-a", false},
+		{">=1.0", "dev-feature-a", false},
+		{">dev-feature-a", "dev-feature-b", false},
+		{"<dev-feature-b", "dev-feature-a", false},
+		{"^1.0 || <0.5", "dev-feature-a", false},
+		{"^1.0 || !=0.5", "dev-feature-a", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint+" "+tc.version, func(t *testing.T) {
+			c, err := NewConstraint(tc.constraint)
+			if assert.NoError(t, err) {
+				version, err := NewVersion(tc.version)
+				if assert.NoError(t, err) {
+					assert.Equal(t, tc.matches, c.Matches(version))
+					assert.Equal(t, tc.matches, c.Intervals().Matches(version))
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkParseConstraintsSimple(b *testing.B) {
 	cases := []struct {
 		name       string
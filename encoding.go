@@ -0,0 +1,157 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler, so versions can be used in JSON, YAML or TOML configuration
+// structs. It returns the original version string
+func (v *Version) MarshalText() ([]byte, error) {
+	return []byte(v.Pretty()), nil
+}
+
+// UnmarshalText parses and validates the version with NewVersion, versions which are only valid SemVer 2.0.0
+// are parsed with NewStrictVersion. The parse error is returned for invalid input
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := NewVersion(string(text))
+
+	if nil != err {
+		strict, strictErr := NewStrictVersion(string(text))
+
+		if nil != strictErr {
+			return err
+		}
+
+		parsed = strict
+	}
+
+	*v = *parsed
+
+	return nil
+}
+
+// MarshalJSON encodes the original version string as a JSON string
+func (v *Version) MarshalJSON() ([]byte, error) {
+	text, _ := v.MarshalText()
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a JSON string and parses it like UnmarshalText
+func (v *Version) UnmarshalJSON(data []byte) error {
+	var text string
+
+	if err := json.Unmarshal(data, &text); nil != err {
+		return fmt.Errorf("unable to decode version %s: a string is expected", data)
+	}
+
+	return v.UnmarshalText([]byte(text))
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the original constraint string. Constraints built
+// with NewMultiConstraint are formatted instead, which fails for an OR inside an AND as there is no syntax for it
+func (c *Constraint) MarshalText() ([]byte, error) {
+	if "" != c.pretty {
+		return []byte(c.pretty), nil
+	}
+
+	text, err := c.format()
+
+	if nil != err {
+		return nil, err
+	}
+
+	return []byte(text), nil
+}
+
+// UnmarshalText parses and validates the constraint with NewConstraint, returning the parse error for invalid
+// input
+func (c *Constraint) UnmarshalText(text []byte) error {
+	parsed, err := NewConstraint(string(text))
+
+	if nil != err {
+		return err
+	}
+
+	*c = *parsed
+
+	return nil
+}
+
+// MarshalJSON encodes the original constraint string as a JSON string
+func (c *Constraint) MarshalJSON() ([]byte, error) {
+	text, err := c.MarshalText()
+
+	if nil != err {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a JSON string and parses it like UnmarshalText
+func (c *Constraint) UnmarshalJSON(data []byte) error {
+	var text string
+
+	if err := json.Unmarshal(data, &text); nil != err {
+		return fmt.Errorf("unable to decode constraint %s: a string is expected", data)
+	}
+
+	return c.UnmarshalText([]byte(text))
+}
+
+// format returns a string which NewConstraint parses into an equivalent constraint
+func (c *Constraint) format() (string, error) {
+	switch c.Kind() {
+	case KindMatchAll:
+		return "*", nil
+	case KindMatchNone:
+		return ">0 <0", nil
+	case KindComparison:
+		return c.operator + c.formatVersion(), nil
+	}
+
+	glue := " || "
+	if c.conjunctive {
+		glue = " "
+	}
+
+	parts := make([]string, 0, len(c.constraints))
+
+	for _, constraint := range c.constraints {
+		if c.conjunctive && KindOr == constraint.Kind() {
+			return "", fmt.Errorf("unable to format constraint %s: an OR constraint can not be part of an AND constraint", c.String())
+		}
+
+		part, err := constraint.format()
+
+		if nil != err {
+			return "", err
+		}
+
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, glue), nil
+}
+
+// formatVersion returns the version of a comparison node in a form which parses back to the same version.
+// Stable versions need an explicit stability for < and >=, as the parser would add "-dev" otherwise
+func (c *Constraint) formatVersion() string {
+	v := c.version
+
+	switch {
+	case v.isStrict && "" != v.Original:
+		return v.Original
+	case 9999999 == v.Major && !v.isBranch && !v.isDate:
+		return "dev-master"
+	case v.isBranch:
+		return v.String()
+	case ("<" == c.operator || ">=" == c.operator) && "" == v.Stability && "" == v.State:
+		return v.String() + "-stable"
+	}
+
+	return v.String()
+}
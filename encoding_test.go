@@ -0,0 +1,142 @@
+package semver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type manifest struct {
+	Version    *Version    `json:"version"`
+	Constraint *Constraint `json:"constraint"`
+}
+
+func TestVersionMarshalText(t *testing.T) {
+	cases := []string{"1.0.0", "v1.2", "1.0.0-beta2", "2010.01.02", "dev-feature/foo", "1.0.x-dev", "dev-master", "1.0.0-alpha.beta.1"}
+
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			v, err := NewVersion(tc)
+			if nil != err {
+				v, err = NewStrictVersion(tc)
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			text, err := v.MarshalText()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tc, string(text))
+
+			var decoded Version
+			if assert.NoError(t, decoded.UnmarshalText(text)) {
+				assert.Equal(t, v.String(), decoded.String())
+				assert.Equal(t, tc, decoded.Original)
+			}
+		})
+	}
+}
+
+func TestConstraintMarshalText(t *testing.T) {
+	cases := []string{"^1.2", "~1.2 || >=2.0 <2.5", "*", "1.0 - 2.0", "dev-foo", "1.0.x-dev#abc123", ">=1.0@dev"}
+
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			c, err := NewConstraint(tc)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			text, err := c.MarshalText()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tc, string(text))
+
+			var decoded Constraint
+			if assert.NoError(t, decoded.UnmarshalText(text)) {
+				assert.Equal(t, c.String(), decoded.String())
+			}
+		})
+	}
+}
+
+func TestConstraintMarshalTextFormatsBuiltConstraints(t *testing.T) {
+	v1, _ := NewVersion("1.0.0")
+	v2, _ := NewVersion("2.0-dev")
+	master, _ := NewVersion("dev-master")
+
+	low, _ := NewComparisonConstraint(">=", v1)
+	high, _ := NewComparisonConstraint("<", v2)
+	branch, _ := NewComparisonConstraint("==", master)
+
+	cases := []struct {
+		constraint *Constraint
+		text       string
+	}{
+		{NewMultiConstraint([]*Constraint{low, high}, true), ">=1.0.0.0-stable <2.0.0.0-dev"},
+		{NewMultiConstraint([]*Constraint{NewMultiConstraint([]*Constraint{low, high}, true), branch}, false), ">=1.0.0.0-stable <2.0.0.0-dev || ==dev-master"},
+		{NewMultiConstraint(nil, false), ">0 <0"},
+		{NewMultiConstraint(nil, true), "*"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.text, func(t *testing.T) {
+			text, err := tc.constraint.MarshalText()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tc.text, string(text))
+
+			parsed, err := NewConstraint(string(text))
+			if assert.NoError(t, err) {
+				assert.True(t, parsed.Intervals().Equal(tc.constraint.Intervals()))
+			}
+		})
+	}
+
+	_, err := NewMultiConstraint([]*Constraint{low, NewMultiConstraint([]*Constraint{high, branch}, false)}, true).MarshalText()
+	assert.Error(t, err)
+}
+
+func TestMarshalJSON(t *testing.T) {
+	var m manifest
+
+	err := json.Unmarshal([]byte(`{"version": "v1.2.0-RC1", "constraint": "^1.2 || dev-main"}`), &m)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "1.2.0.0-RC1", m.Version.String())
+	assert.True(t, m.Constraint.Matches(m.Version))
+
+	data, err := json.Marshal(m)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"version": "v1.2.0-RC1", "constraint": "^1.2 || dev-main"}`, string(data))
+	}
+
+	data, err = json.Marshal(manifest{})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"version": null, "constraint": null}`, string(data))
+	}
+}
+
+func TestUnmarshalJSONFails(t *testing.T) {
+	var m manifest
+
+	err := json.Unmarshal([]byte(`{"version": "1.0.0.0.0"}`), &m)
+	assert.EqualError(t, err, "unable to parse version 1.0.0.0.0")
+
+	err = json.Unmarshal([]byte(`{"constraint": "~=1.4"}`), &m)
+	_, ok := err.(*ConstraintParseError)
+	assert.True(t, ok, "expected a *ConstraintParseError, got %T", err)
+
+	err = json.Unmarshal([]byte(`{"version": 1}`), &m)
+	assert.EqualError(t, err, "unable to decode version 1: a string is expected")
+}
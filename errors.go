@@ -0,0 +1,121 @@
+package semver
+
+import (
+	"fmt"
+)
+
+// UnsatisfiableConstraintError is returned by NewSatisfiableConstraint when an AND group of the
+// constraint can never match any version, e.g. ">=2.0 <1.0"
+type UnsatisfiableConstraintError struct {
+	Constraint string
+	Group      string
+}
+
+func (e *UnsatisfiableConstraintError) Error() string {
+	return fmt.Sprintf("constraint %s can never be satisfied: %s does not match any version", e.Constraint, e.Group)
+}
+
+// ParseErrorReason is a machine readable code describing why a version or constraint could not be parsed
+type ParseErrorReason string
+
+const (
+	// ReasonInvalidVersion is used for versions which do not match any of the supported formats
+	ReasonInvalidVersion ParseErrorReason = "invalid-version"
+	// ReasonInvalidConstraint is used for constraints which do not match any of the supported formats
+	ReasonInvalidConstraint ParseErrorReason = "invalid-constraint"
+	// ReasonInvalidOperator is used for unsupported operators, e.g. "~>"
+	ReasonInvalidOperator ParseErrorReason = "invalid-operator"
+	// ReasonNumberOverflow is used for version numbers which do not fit into an int
+	ReasonNumberOverflow ParseErrorReason = "number-overflow"
+)
+
+// VersionParseError is returned by NewVersion and NewStrictVersion if the input is not a valid version
+type VersionParseError struct {
+	// Input is the string passed to the parser
+	Input string
+	// Version is the part of the input which could not be parsed, either side of an inline "as" alias
+	Version string
+	Reason  ParseErrorReason
+	Strict  bool
+	Err     error
+}
+
+func (e *VersionParseError) Error() string {
+	message := "unable to parse version " + e.Version
+
+	if e.Strict {
+		message = "unable to parse strict version " + e.Version
+	}
+
+	if nil != e.Err {
+		message += ": " + e.Err.Error()
+	}
+
+	return message
+}
+
+// Unwrap returns the underlying error, if any
+func (e *VersionParseError) Unwrap() error {
+	return e.Err
+}
+
+/*
+ Constraint Parse Error
+
+ Returned by NewConstraint if one of the constraints split by "||", "," or whitespace could not be parsed.
+ Constraint is the failing part exactly as it appears in the input, starting at the byte Offset, so
+ Input[Offset:Offset+len(Constraint)] points at the bad token. Invalid versions are available through Err.
+ For common mistakes, like constraints written for npm, pip or Maven, Suggestion contains the whole input
+ rewritten as a valid constraint.
+*/
+type ConstraintParseError struct {
+	Input      string
+	Constraint string
+	Offset     int
+	Reason     ParseErrorReason
+	Suggestion string
+	Err        error
+}
+
+func (e *ConstraintParseError) Error() string {
+	if ReasonInvalidOperator == e.Reason {
+		return fmt.Sprintf(`Could not parse version constraint %s: `+
+			`Invalid operator "~>", you probably meant to use the "~" operator`, e.Constraint)
+	}
+
+	message := "unable to parse constraint " + e.Constraint
+
+	if nil != e.Err {
+		message += ": " + e.Err.Error()
+	}
+
+	if "" != e.Suggestion {
+		message += fmt.Sprintf(`, did you mean "%s"?`, e.Suggestion)
+	}
+
+	return message
+}
+
+// Unwrap returns the underlying error, e.g. the *VersionParseError of an invalid version
+func (e *ConstraintParseError) Unwrap() error {
+	return e.Err
+}
+
+// newConstraintParseError attaches the position of the failing part of the input to an error of the parser
+func newConstraintParseError(input string, start int, end int, err error) *ConstraintParseError {
+	parseErr, ok := err.(*ConstraintParseError)
+
+	if !ok {
+		parseErr = &ConstraintParseError{Reason: ReasonInvalidConstraint, Err: err}
+
+		if versionErr, ok := err.(*VersionParseError); ok {
+			parseErr.Reason = versionErr.Reason
+		}
+	}
+
+	parseErr.Input = input
+	parseErr.Constraint = input[start:end]
+	parseErr.Offset = start
+
+	return parseErr
+}
@@ -0,0 +1,489 @@
+package semver
+
+import (
+	"sort"
+)
+
+// Interval is a contiguous numeric range of versions. Start is always a > or >= constraint
+// and End is always a < or <= constraint
+type Interval struct {
+	Start *Constraint
+	End   *Constraint
+}
+
+// Branches holds the dev-* branch names matched by a constraint. If Exclude is true,
+// every branch except the listed names is matched
+type Branches struct {
+	Names   []string
+	Exclude bool
+}
+
+// Intervals is the normalized form of a constraint, a sorted list of non-overlapping
+// numeric intervals and the set of dev-* branches which are matched
+type Intervals struct {
+	Numeric  []Interval
+	Branches Branches
+}
+
+type border struct {
+	version  *Version
+	operator string
+	start    bool
+}
+
+type borders []border
+
+// maxInt is used as the major version of the positive infinity bound
+const maxInt = int(^uint(0) >> 1)
+
+var opSortOrder = map[string]int{">=": -3, "<": -2, ">": 2, "<=": 3}
+
+func (b borders) Len() int {
+	return len(b)
+}
+
+func (b borders) Swap(i, j int) {
+	b[i], b[j] = b[j], b[i]
+}
+
+func (b borders) Less(i, j int) bool {
+	if d := compare(b[i].version, b[j].version); d != Equal {
+		return LessThan == d
+	}
+
+	return opSortOrder[b[i].operator] < opSortOrder[b[j].operator]
+}
+
+func fromZero() *Constraint {
+	return &Constraint{operator: ">=", version: &Version{Stability: "dev"}, conjunctive: true}
+}
+
+func untilPositiveInfinity() *Constraint {
+	return &Constraint{operator: "<", version: &Version{Major: maxInt}, conjunctive: true}
+}
+
+func noDev() Branches {
+	return Branches{Names: []string{}, Exclude: false}
+}
+
+func anyDev() Branches {
+	return Branches{Names: []string{}, Exclude: true}
+}
+
+func isSameBound(a *Constraint, b *Constraint) bool {
+	return a.operator == b.operator && Equal == compare(a.version, b.version)
+}
+
+func isZero(c *Constraint) bool {
+	return isSameBound(c, fromZero())
+}
+
+func isPositiveInfinity(c *Constraint) bool {
+	return isSameBound(c, untilPositiveInfinity())
+}
+
+// Intervals compiles the constraint into its normalized list of numeric intervals and dev-* branches
+func (c *Constraint) Intervals() Intervals {
+	return generateIntervals(c)
+}
+
+// Matches checks if the version falls into one of the intervals
+func (i Intervals) Matches(version *Version) bool {
+	if version.isBranch {
+		return i.Branches.Exclude != containsName(i.Branches.Names, version.String())
+	}
+
+	// the intervals are sorted and never overlap, so we only have to check the first
+	// interval which ends after the version
+	index := sort.Search(len(i.Numeric), func(n int) bool {
+		return version.Compare(i.Numeric[n].End.version, i.Numeric[n].End.operator)
+	})
+
+	if index == len(i.Numeric) {
+		return false
+	}
+
+	return version.Compare(i.Numeric[index].Start.version, i.Numeric[index].Start.operator)
+}
+
+// isSatisfiable checks if the intervals contain at least one version or branch
+func (i Intervals) isSatisfiable() bool {
+	return len(i.Numeric) > 0 || len(i.Branches.Names) > 0 || i.Branches.Exclude
+}
+
+// Equal checks if both intervals match exactly the same versions
+func (i Intervals) Equal(other Intervals) bool {
+	if len(i.Numeric) != len(other.Numeric) || i.Branches.Exclude != other.Branches.Exclude {
+		return false
+	}
+
+	for n, interval := range i.Numeric {
+		if !isSameBound(interval.Start, other.Numeric[n].Start) || !isSameBound(interval.End, other.Numeric[n].End) {
+			return false
+		}
+	}
+
+	if len(i.Branches.Names) != len(other.Branches.Names) {
+		return false
+	}
+
+	return 0 == len(diffNames(i.Branches.Names, other.Branches.Names))
+}
+
+func (i Interval) String() string {
+	return "[" + i.Start.String() + " " + i.End.String() + "]"
+}
+
+/*
+ Intervals
+
+ Converts a constraint tree into a sorted list of non-overlapping numeric intervals and a set of dev-* branch
+ names, following the same rules as Composer's Intervals::get(). Two constraints which match the same versions
+ always produce the same intervals.
+*/
+func generateIntervals(c *Constraint) Intervals {
+	if c.isEmpty {
+		return Intervals{Numeric: []Interval{{fromZero(), untilPositiveInfinity()}}, Branches: anyDev()}
+	}
+
+	if 0 == len(c.constraints) {
+		if nil == c.version {
+			return Intervals{Numeric: []Interval{}, Branches: noDev()}
+		}
+
+		return generateSingleConstraintIntervals(c)
+	}
+
+	numericGroups := make([][]Interval, 0, len(c.constraints))
+	constraintBranches := make([]Branches, 0, len(c.constraints))
+
+	for _, constraint := range c.constraints {
+		res := generateIntervals(constraint)
+		numericGroups = append(numericGroups, res.Numeric)
+		constraintBranches = append(constraintBranches, res.Branches)
+	}
+
+	branches := mergeBranches(constraintBranches, c.conjunctive)
+
+	if 1 == len(numericGroups) {
+		return Intervals{Numeric: numericGroups[0], Branches: branches}
+	}
+
+	var list borders
+	for _, group := range numericGroups {
+		for _, i := range group {
+			list = append(list, border{version: i.Start.version, operator: i.Start.operator, start: true})
+			list = append(list, border{version: i.End.version, operator: i.End.operator, start: false})
+		}
+	}
+
+	sort.Stable(list)
+
+	var (
+		activeIntervals     = 0
+		activationThreshold = 1
+		intervals           = []Interval{}
+		start               *Constraint
+	)
+
+	if c.conjunctive {
+		activationThreshold = len(numericGroups)
+	}
+
+	for _, b := range list {
+		if b.start {
+			activeIntervals++
+		} else {
+			activeIntervals--
+		}
+
+		if nil == start && activeIntervals >= activationThreshold {
+			start = &Constraint{operator: b.operator, version: b.version, conjunctive: true}
+		} else if nil != start && activeIntervals < activationThreshold {
+			// filter out invalid intervals like > x - <= x, or >= x - < x
+			if Equal == compare(start.version, b.version) &&
+				((">" == start.operator && "<=" == b.operator) || (">=" == start.operator && "<" == b.operator)) {
+				start = nil
+				continue
+			}
+
+			intervals = append(intervals, Interval{start, &Constraint{operator: b.operator, version: b.version, conjunctive: true}})
+			start = nil
+		}
+	}
+
+	return Intervals{Numeric: intervals, Branches: branches}
+}
+
+func generateSingleConstraintIntervals(c *Constraint) Intervals {
+	operator := c.operator
+
+	// handle branch constraints first, > and < can never match a branch
+	if c.version.isBranch {
+		switch operator {
+		case "!=":
+			return Intervals{
+				Numeric:  []Interval{{fromZero(), untilPositiveInfinity()}},
+				Branches: Branches{Names: []string{c.version.String()}, Exclude: true},
+			}
+		case "==":
+			return Intervals{Numeric: []Interval{}, Branches: Branches{Names: []string{c.version.String()}}}
+		}
+
+		return Intervals{Numeric: []Interval{}, Branches: noDev()}
+	}
+
+	switch operator {
+	case ">", ">=":
+		return Intervals{Numeric: []Interval{{c, untilPositiveInfinity()}}, Branches: noDev()}
+	case "<", "<=":
+		return Intervals{Numeric: []Interval{{fromZero(), c}}, Branches: noDev()}
+	case "!=":
+		return Intervals{
+			Numeric: []Interval{
+				{fromZero(), &Constraint{operator: "<", version: c.version, conjunctive: true}},
+				{&Constraint{operator: ">", version: c.version, conjunctive: true}, untilPositiveInfinity()},
+			},
+			Branches: anyDev(),
+		}
+	}
+
+	return Intervals{
+		Numeric: []Interval{{
+			&Constraint{operator: ">=", version: c.version, conjunctive: true},
+			&Constraint{operator: "<=", version: c.version, conjunctive: true},
+		}},
+		Branches: noDev(),
+	}
+}
+
+func mergeBranches(sets []Branches, conjunctive bool) Branches {
+	var branches Branches
+
+	if conjunctive {
+		branches = anyDev()
+
+		for _, b := range sets {
+			if b.Exclude {
+				if branches.Exclude {
+					// !=a && !=b => !=a,!=b
+					branches.Names = append(branches.Names, b.Names...)
+				} else {
+					// (==a||==c) && !=a,!=b => ==c
+					branches.Names = diffNames(branches.Names, b.Names)
+				}
+			} else {
+				if branches.Exclude {
+					// !=a,!=b && (==a||==c) => ==c
+					branches.Names = diffNames(b.Names, branches.Names)
+					branches.Exclude = false
+				} else {
+					// (==a||==b) && (==a||==c) => ==a
+					branches.Names = intersectNames(branches.Names, b.Names)
+				}
+			}
+		}
+	} else {
+		branches = noDev()
+
+		for _, b := range sets {
+			if b.Exclude {
+				if branches.Exclude {
+					// !=a,!=b || !=b,!=c => !=b
+					branches.Names = intersectNames(branches.Names, b.Names)
+				} else {
+					// (==b || ==c) || !=a,!=b => !=a
+					branches.Exclude = true
+					branches.Names = diffNames(b.Names, branches.Names)
+				}
+			} else {
+				if branches.Exclude {
+					// !=a,!=b || (==b || ==c) => !=a
+					branches.Names = diffNames(branches.Names, b.Names)
+				} else {
+					// (==a || ==b) || ==c => ==a || ==b || ==c
+					branches.Names = append(branches.Names, b.Names...)
+				}
+			}
+		}
+	}
+
+	branches.Names = uniqueNames(branches.Names)
+
+	return branches
+}
+
+/*
+ Compact Constraint
+
+ Rebuilds the smallest constraint tree which matches the same versions as the given constraint, e.g.
+ [>= 1.0 < 2.0] || [>= 2.0 < 3.0] becomes [>= 1.0 < 3.0], and [>= 1.0 < 1.5] || [> 1.5 < 2.0] becomes
+ [>= 1.0 != 1.5 < 2.0]
+*/
+func compactConstraint(c *Constraint) *Constraint {
+	if 0 == len(c.constraints) {
+		return c
+	}
+
+	intervals := generateIntervals(c)
+	var (
+		constraints        []*Constraint
+		hasNumericMatchAll = false
+	)
+
+	if 1 == len(intervals.Numeric) && isZero(intervals.Numeric[0].Start) && isPositiveInfinity(intervals.Numeric[0].End) {
+		constraints = append(constraints, intervals.Numeric[0].Start)
+		hasNumericMatchAll = true
+	} else {
+		var unEqualConstraints []*Constraint
+		count := len(intervals.Numeric)
+
+		for i := 0; i < count; i++ {
+			current := intervals.Numeric[i]
+
+			// if the current interval ends with < N and the next interval begins with > N, we can swap this out
+			// for != N, so [>= M < N] || [> N < P] becomes [>= M != N < P]
+			if "<" == current.End.operator && i+1 < count {
+				next := intervals.Numeric[i+1]
+
+				if ">" == next.Start.operator && Equal == compare(current.End.version, next.Start.version) {
+					if 0 == len(unEqualConstraints) && !isZero(current.Start) {
+						unEqualConstraints = append(unEqualConstraints, current.Start)
+					}
+
+					unEqualConstraints = append(unEqualConstraints, &Constraint{operator: "!=", version: current.End.version, conjunctive: true})
+					continue
+				}
+			}
+
+			if len(unEqualConstraints) > 0 {
+				if !isPositiveInfinity(current.End) {
+					unEqualConstraints = append(unEqualConstraints, current.End)
+				}
+
+				if len(unEqualConstraints) > 1 {
+					constraints = append(constraints, &Constraint{constraints: unEqualConstraints, conjunctive: true})
+				} else {
+					constraints = append(constraints, unEqualConstraints[0])
+				}
+
+				unEqualConstraints = nil
+				continue
+			}
+
+			// convert >= x - <= x intervals back to == x
+			if ">=" == current.Start.operator && "<=" == current.End.operator && Equal == compare(current.Start.version, current.End.version) {
+				constraints = append(constraints, &Constraint{operator: "==", version: current.Start.version, conjunctive: true})
+				continue
+			}
+
+			if isZero(current.Start) {
+				constraints = append(constraints, current.End)
+			} else if isPositiveInfinity(current.End) {
+				constraints = append(constraints, current.Start)
+			} else {
+				constraints = append(constraints, &Constraint{constraints: []*Constraint{current.Start, current.End}, conjunctive: true})
+			}
+		}
+	}
+
+	if 0 == len(intervals.Branches.Names) {
+		if intervals.Branches.Exclude && hasNumericMatchAll {
+			return &Constraint{isEmpty: true}
+		}
+	} else {
+		devConstraints := make([]*Constraint, 0, len(intervals.Branches.Names))
+		operator := "=="
+
+		if intervals.Branches.Exclude {
+			operator = "!="
+		}
+
+		for _, name := range intervals.Branches.Names {
+			version, err := NewVersion(name)
+
+			if nil != err {
+				continue
+			}
+
+			devConstraints = append(devConstraints, &Constraint{operator: operator, version: version, conjunctive: true})
+		}
+
+		// excluded branches are conjunctive with the numeric intervals, so > 2.0 != dev-foo
+		// must return a conjunctive constraint
+		if intervals.Branches.Exclude {
+			if len(constraints) > 1 {
+				return &Constraint{constraints: append([]*Constraint{{constraints: constraints, conjunctive: false}}, devConstraints...), conjunctive: true}
+			}
+
+			if 1 == len(constraints) && isZero(constraints[0]) {
+				if len(devConstraints) > 1 {
+					return &Constraint{constraints: devConstraints, conjunctive: true}
+				}
+
+				return devConstraints[0]
+			}
+
+			return &Constraint{constraints: append(constraints, devConstraints...), conjunctive: true}
+		}
+
+		// otherwise the branches are == constraints which are disjunctive with the rest of the constraint
+		constraints = append(constraints, devConstraints...)
+	}
+
+	if len(constraints) > 1 {
+		return &Constraint{constraints: constraints, conjunctive: false}
+	}
+
+	if 1 == len(constraints) {
+		return constraints[0]
+	}
+
+	return &Constraint{}
+}
+
+func diffNames(a []string, b []string) []string {
+	result := []string{}
+
+	for _, name := range a {
+		if !containsName(b, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+func intersectNames(a []string, b []string) []string {
+	result := []string{}
+
+	for _, name := range a {
+		if containsName(b, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+func uniqueNames(names []string) []string {
+	result := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if !containsName(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,116 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConstraintIntervals(t *testing.T) {
+	cases := []struct {
+		constraint string
+		numeric    []string
+		branches   []string
+		exclude    bool
+	}{
+		{"^1.2", []string{"[>= 1.2.0.0-dev < 2.0.0.0-dev]"}, []string{}, false},
+		{">=1.0 <2.0 || >=1.5 <3.0", []string{"[>= 1.0.0.0-dev < 3.0.0.0-dev]"}, []string{}, false},
+		{"^1.0 || ^3.0", []string{"[>= 1.0.0.0-dev < 2.0.0.0-dev]", "[>= 3.0.0.0-dev < 4.0.0.0-dev]"}, []string{}, false},
+		{">=1.0 <1.5", []string{"[>= 1.0.0.0-dev < 1.5.0.0-dev]"}, []string{}, false},
+		{"1.2.3", []string{"[>= 1.2.3.0 <= 1.2.3.0]"}, []string{}, false},
+		{">=2.0 <1.0", []string{}, []string{}, false},
+		{"!=1.5", []string{"[>= 0.0.0.0-dev < 1.5.0.0]", "[> 1.5.0.0 < 9223372036854775807.0.0.0]"}, []string{}, true},
+		{"*", []string{"[>= 0.0.0.0-dev < 9223372036854775807.0.0.0]"}, []string{}, true},
+		{"dev-foo || dev-bar", []string{}, []string{"dev-foo", "dev-bar"}, false},
+		{"!=dev-foo", []string{"[>= 0.0.0.0-dev < 9223372036854775807.0.0.0]"}, []string{"dev-foo"}, true},
+		{"dev-foo || ^1.0", []string{"[>= 1.0.0.0-dev < 2.0.0.0-dev]"}, []string{"dev-foo"}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			c, err := NewConstraint(tc.constraint)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			intervals := c.Intervals()
+			numeric := make([]string, 0, len(intervals.Numeric))
+
+			for _, i := range intervals.Numeric {
+				numeric = append(numeric, i.String())
+			}
+
+			assert.Equal(t, tc.numeric, numeric)
+			assert.Equal(t, tc.branches, intervals.Branches.Names)
+			assert.Equal(t, tc.exclude, intervals.Branches.Exclude)
+		})
+	}
+}
+
+func TestIntervalsEqual(t *testing.T) {
+	cases := []struct {
+		constraintA string
+		constraintB string
+		equal       bool
+	}{
+		{">=1.0 <2.0 || >=1.5 <3.0", ">=1.0 <3.0", true},
+		{"~1.2", "^1.2", true},
+		{"1.*", "^1.0", true},
+		{"1.0 - 2.0", ">=1.0 <2.1", true},
+		{"dev-foo || dev-bar", "dev-bar || dev-foo", true},
+		{"^1.0", "^1.1", false},
+		{"^1.0", "^1.0 || dev-foo", false},
+		{"*", ">=0", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraintA+" == "+tc.constraintB, func(t *testing.T) {
+			a, err := NewConstraint(tc.constraintA)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			b, err := NewConstraint(tc.constraintB)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.equal, a.Intervals().Equal(b.Intervals()))
+			}
+		})
+	}
+}
+
+func TestIntervalsMatches(t *testing.T) {
+	constraints := []string{"^1.0", "^1.0 || ^3.0", "!=1.5", ">=1.0 <1.5 || >1.5 <2.0", "*", ">=2.0 <1.0", "1.2.3", "~1.2.3 != 1.2.5"}
+	versions := []string{"0.9.0", "1.0.0", "1.2.3", "1.2.5", "1.4.9", "1.5.0", "1.5.1", "2.0.0", "2.0.0-beta", "3.1.0", "4.0.0"}
+
+	for _, constraint := range constraints {
+		c, err := NewConstraint(constraint)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		intervals := c.Intervals()
+
+		for _, v := range versions {
+			version, err := NewVersion(v)
+			if assert.NoError(t, err) {
+				assert.Equal(t, c.Matches(version), intervals.Matches(version), "%s matching %s", constraint, v)
+			}
+		}
+	}
+}
+
+func TestIntervalsMatchesBranches(t *testing.T) {
+	c, _ := NewConstraint("dev-foo || ^1.0")
+	intervals := c.Intervals()
+
+	foo, _ := NewVersion("dev-foo")
+	bar, _ := NewVersion("dev-bar")
+
+	assert.True(t, intervals.Matches(foo))
+	assert.False(t, intervals.Matches(bar))
+
+	c, _ = NewConstraint("!=dev-foo")
+	intervals = c.Intervals()
+
+	assert.False(t, intervals.Matches(foo))
+	assert.True(t, intervals.Matches(bar))
+}
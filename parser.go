@@ -2,41 +2,54 @@ package semver
 
 import (
 	"fmt"
-	"github.com/spf13/cast"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
 var (
 	aliasRegex = regexp.MustCompile(`^([^,\s]+)\s+as\s+([^,\s]+)$`)
 
-	stabilityRegex = `[._-]?(?:(dev|stable|beta|b|RC|alpha|a|patch|pl|p)((?:[.-]?\d+)*)?)`
-
 	branchRegex = regexp.MustCompile(`^v?(\d+)(\.(?:\d+|[xX*]))?(\.(?:\d+|[xX*]))?(\.(?:\d+|[xX*]))?$`)
 
-	versionRegex =
-	// Match normal version string (1.2.3)
-	`^v?([0-9]{1,5})(\.[0-9]+)?(\.[0-9]+)?(\.[0-9]+)?` +
+	branchMatcher = regexp.MustCompile(`(?i)(.*?)[.-]?dev$`)
+	replaceRegex  = regexp.MustCompile(`([^0-9]+)`)
+
+	// Match a strict SemVer 2.0.0 version (https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string)
+	strictVersionRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+)
+
+// compileVersionRegexes compiles the regexes of the parser, which match the stabilities of the registry
+func (r *stabilityRegistry) compileVersionRegexes() {
+	r.stabilityRegex = `[._-]?(?:(` + r.alternatives() + `)((?:[.-]?\d+)*)?)`
 
+	// Match normal version string (1.2.3)
+	versionRegex := `^v?([0-9]{1,5})(\.[0-9]+)?(\.[0-9]+)?(\.[0-9]+)?` +
 		// Match pre-release info (-beta.2). This supports dot, underscore, dash or nothing as a prefix to match Composers rules
-		stabilityRegex + "?([.-]?dev)?"
+		r.stabilityRegex + "?([.-]?dev)?"
 
 	// Match metadata (E.G + build.1234)
-	versionRegexC = regexp.MustCompile(`(?i)` + versionRegex + `(\+([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?$`)
+	r.versionRegexC = regexp.MustCompile(`(?i)` + versionRegex + `(\+([0-9A-Za-z\-]+(\.[0-9A-Za-z\-]+)*))?$`)
 
-	dateTimeRegex = regexp.MustCompile(`^v?(\d{4}(?:[.:-]?\d{2}){1,6}(?:[.:-]?\d{1,3})?)` + stabilityRegex + `?$`)
+	r.dateTimeRegex = regexp.MustCompile(`^v?(\d{4}(?:[.:-]?\d{2}){1,6}(?:[.:-]?\d{1,3})?)` + r.stabilityRegex + `?$`)
 
-	stabilityRegexC = regexp.MustCompile(`(?i)` + stabilityRegex)
-	branchMatcher   = regexp.MustCompile(`(?i)(.*?)[.-]?dev$`)
-	replaceRegex    = regexp.MustCompile(`([^0-9]+)`)
-)
+	r.stabilityRegexC = regexp.MustCompile(`(?i)` + r.stabilityRegex)
+}
 
 func NewVersion(version string) (*Version, error) {
+	return currentStabilities().newVersion(version)
+}
+
+// newVersion parses a version with the stabilities of the registry, the registry is loaded once by the
+// exported functions so a single parse never mixes two registries
+func (r *stabilityRegistry) newVersion(version string) (*Version, error) {
 	originalVersion := version
 	alias := aliasRegex.FindStringSubmatch(version)
 
 	if alias != nil {
-		version = alias[1]
+		return r.newAliasedVersion(version, alias[1], alias[2])
 	}
 
 	if match, _ := regexp.Match("(?i)^(?:dev-)?(?:master|trunk|default)$", []byte(version)); match {
@@ -56,12 +69,12 @@ func NewVersion(version string) (*Version, error) {
 		}, nil
 	}
 
-	versionMatch := versionRegexC.FindStringSubmatch(version)
+	versionMatch := r.versionRegexC.FindStringSubmatch(version)
 
 	if versionMatch != nil {
-		stability := expandStability(versionMatch[5])
+		stability := r.expandStability(versionMatch[5])
 		return &Version{
-			Major:      cast.ToInt(versionMatch[1]),
+			Major:      parseVersionNumber(versionMatch[1]),
 			Minor:      parseVersionNumber(versionMatch[2]),
 			Patch:      parseVersionNumber(versionMatch[3]),
 			Extra:      parseVersionNumber(versionMatch[4]),
@@ -73,35 +86,122 @@ func NewVersion(version string) (*Version, error) {
 		}, nil
 	}
 
-	dateTimeMatch := dateTimeRegex.FindStringSubmatch(version)
+	dateTimeMatch := r.dateTimeRegex.FindStringSubmatch(version)
 
 	if dateTimeMatch != nil {
 
 		versionString := replaceRegex.ReplaceAllString(dateTimeMatch[1], `.`)
 
 		return &Version{
-			Stability: expandStability(dateTimeMatch[2]),
-			Patch:     cast.ToInt(dateTimeMatch[3]),
-			Parsed:    versionString,
-			Original:  originalVersion,
-			isDate:    true,
+			Stability:  r.expandStability(dateTimeMatch[2]),
+			Patch:      parseVersionNumber(dateTimeMatch[3]),
+			PreRelease: strings.TrimLeft(dateTimeMatch[3], ".-"),
+			Parsed:     versionString,
+			Original:   originalVersion,
+			isDate:     true,
 		}, nil
 	}
 
 	branchMatches := branchMatcher.FindStringSubmatch(version)
 	if nil != branchMatches {
-		return NormalizeBranch(branchMatches[1])
+		branch, err := r.normalizeBranch(branchMatches[1])
+
+		if nil != err {
+			return nil, err
+		}
+
+		branch.Original = originalVersion
+
+		return branch, nil
+	}
+
+	return nil, &VersionParseError{Input: originalVersion, Version: version, Reason: ReasonInvalidVersion}
+}
+
+// newAliasedVersion parses an inline alias, for "dev-master as 1.0.0" the version is dev-master and its Alias
+// is 1.0.0. The Original of the version is the whole input, so it can be parsed again
+func (r *stabilityRegistry) newAliasedVersion(original string, version string, alias string) (*Version, error) {
+	v, err := r.newVersion(version)
+
+	if nil != err {
+		return nil, &VersionParseError{Input: original, Version: version, Reason: ReasonInvalidVersion}
 	}
 
-	return nil, fmt.Errorf("unable to parse version %s", version)
+	a, err := r.newVersion(alias)
+
+	if nil != err {
+		return nil, &VersionParseError{Input: original, Version: alias, Reason: ReasonInvalidVersion}
+	}
+
+	v.Original = original
+	v.Alias = a
+
+	return v, nil
+}
+
+/*
+ Strict Version
+
+ Parses a version which strictly follows SemVer 2.0.0. Unlike NewVersion there are always exactly three
+ numeric segments, no leading "v" or stability shorthands, and the dot separated pre-release identifiers
+ are kept as they are. Two strict versions are compared using the precedence rules of the specification.
+*/
+func NewStrictVersion(version string) (*Version, error) {
+	matches := strictVersionRegex.FindStringSubmatch(version)
+
+	if nil == matches {
+		return nil, &VersionParseError{Input: version, Version: version, Reason: ReasonInvalidVersion, Strict: true}
+	}
+
+	var segments [3]int
+
+	for i := range segments {
+		number, err := strconv.Atoi(matches[i+1])
+
+		if nil != err {
+			return nil, &VersionParseError{Input: version, Version: version, Reason: ReasonNumberOverflow, Strict: true, Err: err}
+		}
+
+		segments[i] = number
+	}
+
+	return &Version{
+		Major:      segments[0],
+		Minor:      segments[1],
+		Patch:      segments[2],
+		PreRelease: matches[4],
+		Stability:  currentStabilities().strictStability(matches[4]),
+		Metadata:   matches[5],
+		Original:   version,
+		isStrict:   true,
+	}, nil
+}
+
+// strictStability maps the first pre-release identifier to a Composer stability, so strict versions
+// can still be matched against constraints. Unknown identifiers are treated as dev releases
+func (r *stabilityRegistry) strictStability(preRelease string) string {
+	if "" == preRelease {
+		return ""
+	}
+
+	identifier := strings.SplitN(preRelease, ".", 2)[0]
+
+	if stability := r.expandStability(identifier); r.isKnown(stability) && StabilityStable != Stability(stability) {
+		return stability
+	}
+
+	return "dev"
 }
 
 func NormalizeBranch(branch string) (*Version, error) {
+	return currentStabilities().normalizeBranch(branch)
+}
 
+func (r *stabilityRegistry) normalizeBranch(branch string) (*Version, error) {
 	valid := map[string]bool{"master": true, "trunk": true, "default": true}
 
 	if valid[branch] {
-		return NewVersion(branch)
+		return r.newVersion(branch)
 	}
 
 	branchMatches := branchRegex.FindStringSubmatch(branch)
@@ -118,52 +218,87 @@ func NormalizeBranch(branch string) (*Version, error) {
 			}
 		}
 
-		return NewVersion(strings.Replace(versionString, "x", "9999999", -1) + "-dev")
+		return r.newVersion(strings.Replace(versionString, "x", "9999999", -1) + "-dev")
 	}
 
-	return NewVersion("dev-" + branch)
+	return r.newVersion("dev-" + branch)
 }
 
-func expandStability(stability string) string {
-	switch strings.ToLower(stability) {
-	case "alpha", "a":
-		return "alpha"
-	case "beta", "b":
-		return "beta"
-	case "p", "pl":
-		return "patch"
-	case "rc":
-		return "RC"
+/*
+ Numeric Alias Prefix
+
+ Returns the numeric prefix of a numeric dev branch, "1.2." for 1.2.x-dev or "2." for v2.x-dev, following the
+ same rules as NormalizeBranch. The second result is false if the branch isn't a numeric dev branch, like
+ dev-master, or if a wildcard is followed by a number.
+*/
+func ParseNumericAliasPrefix(branch string) (string, bool) {
+	if len(branch) < 4 || "-dev" != strings.ToLower(branch[len(branch)-4:]) {
+		return "", false
+	}
+
+	branchMatches := branchRegex.FindStringSubmatch(branch[:len(branch)-4])
+
+	if nil == branchMatches {
+		return "", false
+	}
+
+	prefix := ""
+	wildcard := false
+
+	for _, segment := range branchMatches[1:] {
+		segment = strings.TrimPrefix(segment, ".")
+
+		if "" == segment {
+			break
+		}
+
+		if !isNumericIdentifier(segment) {
+			wildcard = true
+			continue
+		}
+
+		if wildcard {
+			return "", false
+		}
+
+		prefix += segment + "."
+	}
+
+	return prefix, true
+}
+
+// expandStability returns the registered stability of a name or shorthand, e.g. "RC" for rc, unknown names are
+// returned unchanged
+func (r *stabilityRegistry) expandStability(stability string) string {
+	if known, ok := r.names[strings.ToLower(stability)]; ok {
+		return string(known)
 	}
 
 	return stability
 }
 
 func ParseStability(stability string) string {
+	return currentStabilities().parseStability(stability)
+}
+
+func (r *stabilityRegistry) parseStability(stability string) string {
 	if "" == stability {
 		return stability
 	}
 
 	if len(stability) >= 4 && ("dev-" == strings.ToLower(stability[0:4]) || "-dev" == strings.ToLower(stability[len(stability)-4:])) {
-		return "dev"
+		return string(StabilityDev)
 	}
 
-	stabilityMatch := stabilityRegexC.FindStringSubmatch(stability)
+	stabilityMatch := r.stabilityRegexC.FindStringSubmatch(stability)
 
 	if nil != stabilityMatch {
-		switch strings.ToLower(stabilityMatch[1]) {
-		case "alpha", "a":
-			return "alpha"
-		case "beta", "b":
-			return "beta"
-		case "rc":
-			return "RC"
-		case "dev":
-			return "dev"
+		if known := r.expandStability(stabilityMatch[1]); StabilityPatch != Stability(known) {
+			return known
 		}
 	}
 
-	return "stable"
+	return string(StabilityStable)
 }
 
 func parseVersionNumber(version string) int {
@@ -171,5 +306,8 @@ func parseVersionNumber(version string) int {
 		return 0
 	}
 
-	return cast.ToInt(strings.TrimPrefix(version, "."))
+	// leading zeros are common in dates (2010.08), so the number is always parsed as decimal
+	number, _ := strconv.Atoi(strings.TrimLeft(version, ".-"))
+
+	return number
 }
@@ -33,6 +33,8 @@ func TestNewVersion(t *testing.T) {
 		{"parses dates w/ . as classical", "2010.01.02", "2010.1.2.0"},
 		{"parses dates y.m.Y as classical", "2010.1.555", "2010.1.555.0"},
 		{"parses dates y.m.Y/2 as classical", "2010.10.200", "2010.10.200.0"},
+		{"parses dates y.m as decimal", "2010.08", "2010.8.0.0"},
+		{"parses leading zeros as decimal", "1.09.08", "1.9.8.0"},
 
 		{"strips v/datetime", "v20100102", "20100102"},
 		{"parses dates w/ -", "2010-01-02", "2010.01.02"},
@@ -97,6 +99,54 @@ func TestFailedNewVersion(t *testing.T) {
 	}
 }
 
+func TestVersionParseError(t *testing.T) {
+	_, err := NewVersion("1.0.0-meh as 1.0.0")
+
+	parseErr, ok := err.(*VersionParseError)
+	if assert.True(t, ok, "expected a *VersionParseError, got %T", err) {
+		assert.Equal(t, "1.0.0-meh as 1.0.0", parseErr.Input)
+		assert.Equal(t, "1.0.0-meh", parseErr.Version)
+		assert.Equal(t, ReasonInvalidVersion, parseErr.Reason)
+		assert.EqualError(t, err, "unable to parse version 1.0.0-meh")
+	}
+
+	_, err = NewStrictVersion("99999999999999999999999.0.0")
+
+	parseErr, ok = err.(*VersionParseError)
+	if assert.True(t, ok, "expected a *VersionParseError, got %T", err) {
+		assert.Equal(t, ReasonNumberOverflow, parseErr.Reason)
+		assert.True(t, parseErr.Strict)
+		assert.NotNil(t, parseErr.Unwrap())
+	}
+}
+
+func TestVersionPretty(t *testing.T) {
+	cases := []struct {
+		version    string
+		normalized string
+	}{
+		{"v1.2-b2", "1.2.0.0-beta2"},
+		{"1.0.x-dev", "1.0.9999999.9999999-dev"},
+		{"dev-master", "9999999-dev"},
+		{"dev-feature/foo", "dev-feature/foo"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			v, err := NewVersion(tc.version)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.version, v.Pretty())
+				assert.Equal(t, tc.normalized, v.Normalized())
+			}
+		})
+	}
+
+	v, _ := NewVersion("1.2.3-beta2")
+	next, _ := v.NextMinor()
+	assert.Equal(t, "1.3.0", next.Pretty())
+	assert.Equal(t, "1.2.0", (&Version{Major: 1, Minor: 2}).Pretty())
+}
+
 func TestNormalizeBranch(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -129,6 +179,37 @@ func TestNormalizeBranch(t *testing.T) {
 	}
 }
 
+func TestParseNumericAliasPrefix(t *testing.T) {
+	cases := []struct {
+		branch string
+		prefix string
+		ok     bool
+	}{
+		{"1.2.x-dev", "1.2.", true},
+		{"v2.x-dev", "2.", true},
+		{"1.2-dev", "1.2.", true},
+		{"1.2.3.4-dev", "1.2.3.4.", true},
+		{"1.x.x-dev", "1.", true},
+		{"1.X-DEV", "1.", true},
+		{"1.*-dev", "1.", true},
+		{"1.x.2-dev", "", false},
+		{"1.2.x", "", false},
+		{"dev-master", "", false},
+		{"master-dev", "", false},
+		{"1.2.3.4.5-dev", "", false},
+		{"-dev", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.branch, func(t *testing.T) {
+			prefix, ok := ParseNumericAliasPrefix(tc.branch)
+
+			assert.Equal(t, tc.prefix, prefix)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
 func TestParseStability(t *testing.T) {
 	cases := []struct {
 		stablility string
@@ -166,3 +247,95 @@ func TestParseStability(t *testing.T) {
 		})
 	}
 }
+
+func TestNewStrictVersion(t *testing.T) {
+	cases := []struct {
+		version    string
+		normalized string
+		stability  string
+	}{
+		{"1.0.0", "1.0.0", ""},
+		{"0.0.4", "0.0.4", ""},
+		{"10.20.30", "10.20.30", ""},
+		{"1.0.0-alpha", "1.0.0-alpha", "alpha"},
+		{"1.0.0-alpha.beta.1", "1.0.0-alpha.beta.1", "alpha"},
+		{"1.0.0-rc.1+build.1", "1.0.0-rc.1+build.1", "RC"},
+		{"1.0.0-0.3.7", "1.0.0-0.3.7", "dev"},
+		{"1.0.0-x.7.z.92", "1.0.0-x.7.z.92", "dev"},
+		{"1.0.0-x-y-z.--", "1.0.0-x-y-z.--", "dev"},
+		{"1.0.0+0.build.1-rc.10000aaa-kk-0.1", "1.0.0+0.build.1-rc.10000aaa-kk-0.1", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			version, err := NewStrictVersion(tc.version)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.normalized, version.String())
+				assert.Equal(t, tc.stability, version.Stability)
+			}
+		})
+	}
+}
+
+func TestFailedNewStrictVersion(t *testing.T) {
+	cases := []string{
+		"1",
+		"1.2",
+		"1.2.3.4",
+		"v1.2.3",
+		"01.1.1",
+		"1.2.3-0123",
+		"1.2.3-beta..1",
+		"1.2.3-",
+		"1.2.3+",
+		"1.2.3b1",
+		"1.2.3-beta_1",
+		"dev-master",
+		"1.2.3 ",
+		"99999999999999999999999.0.0",
+	}
+
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			_, err := NewStrictVersion(tc)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPreReleaseIdentifiers(t *testing.T) {
+	cases := []struct {
+		version     string
+		strict      bool
+		identifiers []string
+	}{
+		{"1.0.0", false, []string{}},
+		{"1.0.0-beta", false, []string{}},
+		{"1.0.0-beta2.1", false, []string{"2", "1"}},
+		{"1.0.0-alpha-2.1-3", false, []string{"2", "1", "3"}},
+		{"1.0.0-RC1.10", false, []string{"1", "10"}},
+		{"1.0.0-beta.2", true, []string{"beta", "2"}},
+		{"1.0.0-x.7.z.92", true, []string{"x", "7", "z", "92"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			var (
+				version *Version
+				err     error
+			)
+
+			if tc.strict {
+				version, err = NewStrictVersion(tc.version)
+			} else {
+				version, err = NewVersion(tc.version)
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.identifiers, version.PreReleaseIdentifiers())
+			}
+		})
+	}
+}
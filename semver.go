@@ -0,0 +1,147 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SatisfyOptions controls which versions are picked by MaxSatisfying and MinSatisfying
+type SatisfyOptions struct {
+	// MinimumStability is the least stable version which is accepted, one of dev, alpha, beta, RC, stable or
+	// a stability added with RegisterStability. An empty value accepts every stability
+	MinimumStability string
+
+	// PreferStable picks a stable version if one matches, even if there is a better unstable version
+	PreferStable bool
+}
+
+// Satisfies checks if the version matches the constraint
+func Satisfies(version string, constraint string) (bool, error) {
+	c, err := NewConstraint(constraint)
+
+	if nil != err {
+		return false, err
+	}
+
+	v, err := NewVersion(version)
+
+	if nil != err {
+		return false, err
+	}
+
+	return c.Matches(v), nil
+}
+
+// SatisfiedBy returns all versions which match the constraint, in their original order
+func SatisfiedBy(versions []string, constraint string) ([]string, error) {
+	c, err := NewConstraint(constraint)
+
+	if nil != err {
+		return nil, err
+	}
+
+	collection, err := NewCollection(versions)
+
+	if nil != err {
+		return nil, err
+	}
+
+	result := []string{}
+
+	for _, v := range collection {
+		if c.Matches(v) {
+			result = append(result, v.Original)
+		}
+	}
+
+	return result, nil
+}
+
+// Sort sorts the versions from lowest to highest. Equal versions keep their original order
+func Sort(versions []string) ([]string, error) {
+	collection, err := NewCollection(versions)
+
+	if nil != err {
+		return nil, err
+	}
+
+	sort.Stable(collection)
+
+	return collection.Strings(), nil
+}
+
+// Rsort sorts the versions from highest to lowest. Equal versions keep their original order
+func Rsort(versions []string) ([]string, error) {
+	collection, err := NewCollection(versions)
+
+	if nil != err {
+		return nil, err
+	}
+
+	sort.Stable(sort.Reverse(collection))
+
+	return collection.Strings(), nil
+}
+
+// MaxSatisfying returns the highest version which matches the constraint, or an empty string if none matches
+func MaxSatisfying(versions []string, constraint string, options SatisfyOptions) (string, error) {
+	return bestSatisfying(versions, constraint, options, GreaterThan)
+}
+
+// MinSatisfying returns the lowest version which matches the constraint, or an empty string if none matches
+func MinSatisfying(versions []string, constraint string, options SatisfyOptions) (string, error) {
+	return bestSatisfying(versions, constraint, options, LessThan)
+}
+
+func bestSatisfying(versions []string, constraint string, options SatisfyOptions, direction int) (string, error) {
+	r := currentStabilities()
+	minimumStability := r.expandStability(options.MinimumStability)
+
+	if "" != minimumStability && !r.isKnown(minimumStability) {
+		return "", fmt.Errorf("invalid minimum stability %s", options.MinimumStability)
+	}
+
+	c, err := NewConstraint(constraint)
+
+	if nil != err {
+		return "", err
+	}
+
+	collection, err := NewCollection(versions)
+
+	if nil != err {
+		return "", err
+	}
+
+	var best, bestStable *Version
+
+	for _, v := range collection {
+		stability := ParseStability(v.String())
+
+		if "" != minimumStability && LessThan == compareStability(stability, minimumStability) {
+			continue
+		}
+
+		if !c.Matches(v) {
+			continue
+		}
+
+		if nil == best || direction == compare(v, best) {
+			best = v
+		}
+
+		if "stable" == stability && (nil == bestStable || direction == compare(v, bestStable)) {
+			bestStable = v
+		}
+	}
+
+	if options.PreferStable && nil != bestStable {
+		return bestStable.Original, nil
+	}
+
+	if nil == best {
+		return "", nil
+	}
+
+	return best.Original, nil
+}
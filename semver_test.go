@@ -0,0 +1,132 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSatisfies(t *testing.T) {
+	cases := []struct {
+		version    string
+		constraint string
+		satisfies  bool
+	}{
+		{"1.2.3", "^1.0", true},
+		{"2.0.0", "^1.0", false},
+		{"1.0.0-beta", "~1.0@beta", true},
+		{"0.9.0", "~1.0@beta", false},
+		{"dev-master", "dev-master", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version+" "+tc.constraint, func(t *testing.T) {
+			satisfies, err := Satisfies(tc.version, tc.constraint)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.satisfies, satisfies)
+			}
+		})
+	}
+}
+
+func TestSatisfiesFails(t *testing.T) {
+	_, err := Satisfies("1.0.0-meh", "^1.0")
+	assert.EqualError(t, err, "unable to parse version 1.0.0-meh")
+
+	_, err = Satisfies("1.0.0", "~>1.0")
+	assert.Error(t, err)
+}
+
+func TestSatisfiedBy(t *testing.T) {
+	versions, err := SatisfiedBy([]string{"1.0", "1.2", "1.9999.9999", "2.0", "2.1", "0.9999.9999"}, "~1.0")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"1.0", "1.2", "1.9999.9999"}, versions)
+	}
+
+	versions, err = SatisfiedBy([]string{"1.0", "1.2"}, "^3.0")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{}, versions)
+	}
+
+	versions, err = SatisfiedBy([]string{"dev-feature", "1.5.0"}, "<2.0")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"1.5.0"}, versions)
+	}
+
+	_, err = SatisfiedBy([]string{"1.0", "foo"}, "~1.0")
+	assert.Error(t, err)
+}
+
+func TestSort(t *testing.T) {
+	versions := []string{"1.0", "0.1", "0.1", "3.2.1", "2.4.0-alpha", "2.4.0"}
+
+	sorted, err := Sort(versions)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"0.1", "0.1", "1.0", "2.4.0-alpha", "2.4.0", "3.2.1"}, sorted)
+	}
+
+	sorted, err = Rsort(versions)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"3.2.1", "2.4.0", "2.4.0-alpha", "1.0", "0.1", "0.1"}, sorted)
+	}
+
+	// the input is never modified
+	assert.Equal(t, []string{"1.0", "0.1", "0.1", "3.2.1", "2.4.0-alpha", "2.4.0"}, versions)
+}
+
+func TestSortFails(t *testing.T) {
+	_, err := Sort([]string{"1.0", "foo"})
+	assert.Error(t, err)
+
+	_, err = Rsort([]string{"1.0", "foo"})
+	assert.Error(t, err)
+}
+
+func TestMaxSatisfying(t *testing.T) {
+	versions := []string{"2.2.0", "2.3.0-alpha1", "2.3.1", "2.4.0-beta2", "2.5.0-dev", "3.0.0", "2.4.0-beta1"}
+
+	cases := []struct {
+		name       string
+		constraint string
+		options    SatisfyOptions
+		max        string
+		min        string
+	}{
+		{"any stability", "^2.3@dev", SatisfyOptions{}, "2.5.0-dev", "2.3.0-alpha1"},
+		{"minimum beta", "^2.3@dev", SatisfyOptions{MinimumStability: "beta"}, "2.4.0-beta2", "2.3.1"},
+		{"minimum shorthand", "^2.3@dev", SatisfyOptions{MinimumStability: "b"}, "2.4.0-beta2", "2.3.1"},
+		{"minimum stable", "^2.3@dev", SatisfyOptions{MinimumStability: "stable"}, "2.3.1", "2.3.1"},
+		{"prefer stable", "^2.3@dev", SatisfyOptions{PreferStable: true}, "2.3.1", "2.3.1"},
+		{"prefer stable without stable", "^2.4@dev", SatisfyOptions{PreferStable: true}, "2.5.0-dev", "2.4.0-beta1"},
+		{"no match", "^4.0", SatisfyOptions{}, "", ""},
+		{"no match with stability", "~2.4.0", SatisfyOptions{MinimumStability: "RC"}, "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			max, err := MaxSatisfying(versions, tc.constraint, tc.options)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.max, max)
+			}
+
+			min, err := MinSatisfying(versions, tc.constraint, tc.options)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.min, min)
+			}
+		})
+	}
+}
+
+func TestMaxSatisfyingFails(t *testing.T) {
+	_, err := MaxSatisfying([]string{"1.0.0"}, "^1.0", SatisfyOptions{MinimumStability: "nightly"})
+	assert.EqualError(t, err, "invalid minimum stability nightly")
+
+	_, err = MinSatisfying([]string{"1.0.0", "foo"}, "^1.0", SatisfyOptions{})
+	assert.Error(t, err)
+
+	_, err = MaxSatisfying([]string{"1.0.0"}, "~>1.0", SatisfyOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,205 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+const (
+	sortKeyBranch  byte = 0
+	sortKeyVersion byte = 1
+
+	sortKeyEnd          byte = 0
+	sortKeyNumeric      byte = 1
+	sortKeyAlphanumeric byte = 2
+)
+
+/*
+ Sort Key
+
+ Returns an order preserving binary encoding of the version: comparing two keys with bytes.Compare gives
+ the same result as comparing the versions, so they can be used as keys of a sorted key value store and a
+ constraint's intervals can be scanned as key ranges. Versions which compare as equal, like 1.0 and 1.0.0,
+ have the same key.
+
+ Branches are encoded as 0x00 followed by their name. Other versions are encoded as 0x01, the numeric
+ segments as 8 big endian uint64, the rank of the stability and the pre-release identifiers. Like
+ SortableString, strict pre-releases which are ordered differently by their SemVer precedence than by their
+ stability, like 1.0.0-x.7 or 1.0.0-RC.1, can't be encoded and return an error.
+*/
+func (v *Version) SortKey() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if v.isBranch {
+		buf.WriteByte(sortKeyBranch)
+		buf.WriteString(v.String())
+
+		return buf.Bytes(), nil
+	}
+
+	if err := v.checkStabilityOrder(); nil != err {
+		return nil, err
+	}
+
+	buf.WriteByte(sortKeyVersion)
+
+	parts := v.numericParts()
+	for i := 0; i < sortableParts; i++ {
+		_ = binary.Write(&buf, binary.BigEndian, uint64(partAt(parts, i)))
+	}
+
+	buf.WriteByte(byte(Stability(v.stability()).Rank()))
+
+	for _, identifier := range v.pre() {
+		if isNumericIdentifier(identifier) {
+			identifier = strings.TrimLeft(identifier, "0")
+
+			buf.WriteByte(sortKeyNumeric)
+			_ = binary.Write(&buf, binary.BigEndian, uint32(len(identifier)))
+		} else {
+			buf.WriteByte(sortKeyAlphanumeric)
+			identifier += string(sortKeyEnd)
+		}
+
+		buf.WriteString(identifier)
+	}
+
+	buf.WriteByte(sortKeyEnd)
+
+	return buf.Bytes(), nil
+}
+
+// DecodeSortKey returns a version which is equal to the version encoded by Version.SortKey. The version is
+// normalized and decoded as a Composer version, so the original string, build metadata and whether it was
+// a strict version or a date are not restored
+func DecodeSortKey(key []byte) (*Version, error) {
+	if 0 == len(key) {
+		return nil, fmt.Errorf("unable to decode an empty sort key")
+	}
+
+	if sortKeyBranch == key[0] {
+		return NewVersion(string(key[1:]))
+	}
+
+	if sortKeyVersion != key[0] {
+		return nil, fmt.Errorf("unable to decode sort key %x: invalid header", key)
+	}
+
+	reader := bytes.NewReader(key[1:])
+	parts := make([]int, sortableParts)
+
+	for i := range parts {
+		var part uint64
+
+		if err := binary.Read(reader, binary.BigEndian, &part); nil != err {
+			return nil, fmt.Errorf("unable to decode sort key %x: %s", key, err)
+		}
+
+		parts[i] = int(part)
+	}
+
+	rank, err := reader.ReadByte()
+
+	if nil != err {
+		return nil, fmt.Errorf("unable to decode sort key %x: %s", key, err)
+	}
+
+	stability, ok := stabilityForRank(int(rank))
+
+	if !ok {
+		return nil, fmt.Errorf("unable to decode sort key %x: unknown stability rank %d", key, rank)
+	}
+
+	v := &Version{Major: parts[0], Minor: parts[1], Patch: parts[2], Extra: parts[3], Stability: stability}
+
+	var pre []string
+
+	for {
+		marker, err := reader.ReadByte()
+
+		if nil != err {
+			return nil, fmt.Errorf("unable to decode sort key %x: %s", key, err)
+		}
+
+		if sortKeyEnd == marker {
+			break
+		}
+
+		identifier, err := readSortKeyIdentifier(reader, marker)
+
+		if nil != err {
+			return nil, fmt.Errorf("unable to decode sort key %x: %s", key, err)
+		}
+
+		pre = append(pre, identifier)
+	}
+
+	v.PreRelease = strings.Join(pre, ".")
+
+	if 0 != reader.Len() {
+		return nil, fmt.Errorf("unable to decode sort key %x: unexpected trailing bytes", key)
+	}
+
+	last := len(parts)
+	for last > 0 && 0 == parts[last-1] {
+		last--
+	}
+
+	// dates with more than 4 components can't be represented by the numeric segments
+	if last > 4 {
+		segments := make([]string, last)
+		for i := range segments {
+			segments[i] = fmt.Sprintf("%d", parts[i])
+		}
+
+		v = &Version{Parsed: strings.Join(segments, "."), Stability: v.Stability, PreRelease: v.PreRelease, isDate: true}
+	}
+
+	v.Original = v.String()
+
+	return v, nil
+}
+
+func readSortKeyIdentifier(reader *bytes.Reader, marker byte) (string, error) {
+	switch marker {
+	case sortKeyNumeric:
+		var length uint32
+
+		if err := binary.Read(reader, binary.BigEndian, &length); nil != err {
+			return "", err
+		}
+
+		if int64(length) > int64(reader.Len()) {
+			return "", fmt.Errorf("invalid identifier length %d", length)
+		}
+
+		digits := make([]byte, length)
+		_, _ = reader.Read(digits)
+
+		if 0 == length {
+			return "0", nil
+		}
+
+		return string(digits), nil
+	case sortKeyAlphanumeric:
+		var identifier []byte
+
+		for {
+			b, err := reader.ReadByte()
+
+			if nil != err {
+				return "", err
+			}
+
+			if sortKeyEnd == b {
+				return string(identifier), nil
+			}
+
+			identifier = append(identifier, b)
+		}
+	}
+
+	return "", fmt.Errorf("invalid identifier marker %d", marker)
+}
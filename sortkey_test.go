@@ -0,0 +1,107 @@
+package semver
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func sortKeyVersions(t *testing.T) []*Version {
+	var versions []*Version
+
+	for _, version := range []string{
+		"dev-foo", "dev-bar", "dev-foo-bar", "0.0.1", "1.0.0-dev", "1.0.0-alpha", "1.0.0-alpha2", "1.0.0-beta",
+		"1.0.0-beta2", "1.0.0-beta2.1", "1.0.0-beta10", "1.0.0-beta010", "1.0.0-RC1", "1.0.0", "1.0", "1.0.0.1",
+		"1.0.1", "1.2", "10.0.0", "2.0.x-dev", "dev-master", "20100102", "2010.01.02", "201001021030", "2010.01.02.10.30", "1.0.0-patch1",
+		"1.0.0-b0", "1.0.0+build.5",
+	} {
+		v, err := NewVersion(version)
+		if assert.NoError(t, err) {
+			versions = append(versions, v)
+		}
+	}
+
+	for _, version := range []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-rc.1", "1.0.0-0.3", "1.0.0-beta.x", "1.0.0-beta.x.y", "1.0.0"} {
+		v, err := NewStrictVersion(version)
+		if assert.NoError(t, err) {
+			versions = append(versions, v)
+		}
+	}
+
+	return versions
+}
+
+// sortKey returns the sort key of a version which can be encoded
+func sortKey(t *testing.T, v *Version) []byte {
+	key, err := v.SortKey()
+	assert.NoError(t, err, "encoding %s", v.Original)
+
+	return key
+}
+
+func TestVersionSortKey(t *testing.T) {
+	versions := sortKeyVersions(t)
+
+	for _, a := range versions {
+		for _, b := range versions {
+			assert.Equal(t, compare(a, b), bytes.Compare(sortKey(t, a), sortKey(t, b)), "%s compared to %s", a.Original, b.Original)
+		}
+	}
+}
+
+func TestVersionSortKeyFails(t *testing.T) {
+	// 1.0.0-RC.1 has a lower precedence than 1.0.0-alpha.1 and 1.0.0-x.7 a higher one than 1.0.0-alpha,
+	// but their stabilities are ordered the other way around
+	for _, version := range []string{"1.0.0-RC.1", "1.0.0-x.7", "1.0.0-alpha1", "1.0.0-dev.1"} {
+		v, err := NewStrictVersion(version)
+		if assert.NoError(t, err) {
+			_, err = v.SortKey()
+			assert.Error(t, err, "encoding %s", version)
+		}
+	}
+}
+
+func TestDecodeSortKey(t *testing.T) {
+	for _, v := range sortKeyVersions(t) {
+		t.Run(v.Original, func(t *testing.T) {
+			decoded, err := DecodeSortKey(sortKey(t, v))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.True(t, v.Equal(decoded), "%s decoded as %s", v, decoded)
+			assert.Equal(t, sortKey(t, v), sortKey(t, decoded))
+		})
+	}
+
+	v, _ := NewVersion("1.0.0-beta2.1")
+	decoded, _ := DecodeSortKey(sortKey(t, v))
+	assert.Equal(t, "1.0.0.0-beta2.1", decoded.String())
+
+	v, _ = NewStrictVersion("1.0.0-rc.1")
+	decoded, _ = DecodeSortKey(sortKey(t, v))
+	assert.Equal(t, "1.0.0.0-RC1", decoded.String())
+
+	v, _ = NewVersion("2010.01.02.10.30")
+	decoded, _ = DecodeSortKey(sortKey(t, v))
+	assert.Equal(t, "2010.1.2.10.30", decoded.String())
+}
+
+func TestDecodeSortKeyFails(t *testing.T) {
+	v, _ := NewVersion("1.0.0-beta2")
+	key := sortKey(t, v)
+
+	cases := [][]byte{
+		{},
+		{1, 0, 0},
+		{7},
+		key[:len(key)-1],
+		append(append([]byte{}, key[:len(key)-1]...), 3, 0),
+		append(append([]byte{}, key...), 0),
+	}
+
+	for _, tc := range cases {
+		_, err := DecodeSortKey(tc)
+		assert.Error(t, err, "decoding %x", tc)
+	}
+}
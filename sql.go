@@ -0,0 +1,171 @@
+package semver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+const (
+	// number of numeric segments in a sortable encoding, dates have up to 8 components
+	sortableParts = 8
+	// number of pre-release identifiers in a sortable encoding
+	sortablePreReleaseParts = 4
+	// digits of the largest int
+	sortableDigits = 19
+	// digits of the largest stability rank
+	sortableRankDigits = 3
+)
+
+// Scan implements sql.Scanner, it parses a string or []byte column like UnmarshalText
+func (v *Version) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case string:
+		return v.UnmarshalText([]byte(value))
+	case []byte:
+		return v.UnmarshalText(value)
+	}
+
+	return fmt.Errorf("unable to scan %T into a version, a string is expected", src)
+}
+
+// Value implements driver.Valuer, versions are stored as their original string and nil as NULL
+func (v *Version) Value() (driver.Value, error) {
+	if nil == v {
+		return nil, nil
+	}
+
+	text, err := v.MarshalText()
+
+	return string(text), err
+}
+
+// Scan implements sql.Scanner, it parses a string or []byte column like UnmarshalText
+func (c *Constraint) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case string:
+		return c.UnmarshalText([]byte(value))
+	case []byte:
+		return c.UnmarshalText(value)
+	}
+
+	return fmt.Errorf("unable to scan %T into a constraint, a string is expected", src)
+}
+
+// Value implements driver.Valuer, constraints are stored as their original string and nil as NULL
+func (c *Constraint) Value() (driver.Value, error) {
+	if nil == c {
+		return nil, nil
+	}
+
+	text, err := c.MarshalText()
+
+	if nil != err {
+		return nil, err
+	}
+
+	return string(text), nil
+}
+
+/*
+ Sortable String
+
+ Returns a string which sorts byte by byte in the same order as the versions, so it can be stored in an
+ additional column and used with ORDER BY, or compared in a WHERE clause. Versions are encoded as "1"
+ followed by the numeric segments, the stability and the pre-release identifiers, each padded to a fixed
+ width and using only digits. The string always has 236 digits, so it sorts the same in any collation, but it
+ must be stored in a text column like CHAR(236) rather than as a number.
+
+ Dev branches like dev-foo are sorted by name, which has no fixed width, and return an error like SortKey
+ does for unsupported versions. Pre-releases with more than 4 identifiers, identifiers with more than 19
+ digits, and strict pre-releases which are not ordered like Composer pre-releases (1.0.0-x.7) can't be encoded
+ either.
+*/
+func (v *Version) SortableString() (string, error) {
+	if v.isBranch {
+		return "", fmt.Errorf("unable to encode version %s: branches have no fixed-width encoding", v.String())
+	}
+
+	parts, rank, pre, err := v.sortFields()
+
+	if nil != err {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("1")
+
+	for _, part := range parts {
+		fmt.Fprintf(&buf, "%0*d", sortableDigits, part)
+	}
+
+	fmt.Fprintf(&buf, "%0*d", sortableRankDigits, rank)
+
+	for i := 0; i < sortablePreReleaseParts; i++ {
+		if i >= len(pre) {
+			buf.WriteString("0" + strings.Repeat("0", sortableDigits))
+			continue
+		}
+
+		buf.WriteString("1" + strings.Repeat("0", sortableDigits-len(pre[i])) + pre[i])
+	}
+
+	return buf.String(), nil
+}
+
+// sortFields returns the fields compare uses for a version which isn't a branch: the numeric segments, the
+// rank of the stability and the pre-release identifiers without leading zeros
+func (v *Version) sortFields() ([]int, int, []string, error) {
+	parts := v.numericParts()
+
+	if len(parts) > sortableParts {
+		return nil, 0, nil, fmt.Errorf("unable to encode version %s: more than %d numeric segments", v.String(), sortableParts)
+	}
+
+	parts = append(parts, make([]int, sortableParts-len(parts))...)
+
+	if err := v.checkStabilityOrder(); nil != err {
+		return nil, 0, nil, err
+	}
+
+	pre := v.pre()
+
+	if len(pre) > sortablePreReleaseParts {
+		return nil, 0, nil, fmt.Errorf("unable to encode version %s: more than %d pre-release identifiers", v.String(), sortablePreReleaseParts)
+	}
+
+	trimmed := make([]string, 0, len(pre))
+
+	for _, identifier := range pre {
+		if !isNumericIdentifier(identifier) {
+			return nil, 0, nil, fmt.Errorf("unable to encode version %s: the pre-release identifier %s is not numeric", v.String(), identifier)
+		}
+
+		identifier = strings.TrimLeft(identifier, "0")
+
+		if len(identifier) > sortableDigits {
+			return nil, 0, nil, fmt.Errorf("unable to encode version %s: the pre-release identifier %s has more than %d digits", v.String(), identifier, sortableDigits)
+		}
+
+		trimmed = append(trimmed, identifier)
+	}
+
+	return parts, Stability(v.stability()).Rank(), trimmed, nil
+}
+
+// checkStabilityOrder fails for strict pre-releases which are ordered differently by their precedence than by
+// their stability, like 1.0.0-x.7, or 1.0.0-RC.1 which has a lower precedence than 1.0.0-alpha.1
+func (v *Version) checkStabilityOrder() error {
+	if !v.isStrict || "" == v.PreRelease {
+		return nil
+	}
+
+	first := strings.SplitN(v.PreRelease, ".", 2)[0]
+
+	if !isNumericIdentifier(first) && "alpha" != first && "beta" != first && "rc" != first {
+		return fmt.Errorf("unable to encode version %s: the pre-release %s can't be ordered by stability", v.String(), v.PreRelease)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestVersionScanValue(t *testing.T) {
+	for _, src := range []interface{}{"v1.2.0-beta2", []byte("v1.2.0-beta2")} {
+		var v Version
+
+		if assert.NoError(t, v.Scan(src)) {
+			assert.Equal(t, "1.2.0.0-beta2", v.String())
+
+			value, err := v.Value()
+			if assert.NoError(t, err) {
+				assert.Equal(t, driver.Value("v1.2.0-beta2"), value)
+			}
+		}
+	}
+
+	var v Version
+
+	assert.EqualError(t, v.Scan("1.0.0.0.0"), "unable to parse version 1.0.0.0.0")
+	assert.EqualError(t, v.Scan(int64(1)), "unable to scan int64 into a version, a string is expected")
+	assert.EqualError(t, v.Scan(nil), "unable to scan <nil> into a version, a string is expected")
+
+	value, err := (*Version)(nil).Value()
+	if assert.NoError(t, err) {
+		assert.Nil(t, value)
+	}
+}
+
+func TestConstraintScanValue(t *testing.T) {
+	var c Constraint
+
+	if assert.NoError(t, c.Scan([]byte("^1.2 || dev-main"))) {
+		assert.Equal(t, "[[>= 1.2.0.0-dev < 2.0.0.0-dev] || == dev-main]", c.String())
+
+		value, err := c.Value()
+		if assert.NoError(t, err) {
+			assert.Equal(t, driver.Value("^1.2 || dev-main"), value)
+		}
+	}
+
+	err := c.Scan("~=1.4")
+	_, ok := err.(*ConstraintParseError)
+	assert.True(t, ok, "expected a *ConstraintParseError, got %T", err)
+
+	assert.EqualError(t, c.Scan(1.5), "unable to scan float64 into a constraint, a string is expected")
+
+	value, err := (*Constraint)(nil).Value()
+	if assert.NoError(t, err) {
+		assert.Nil(t, value)
+	}
+}
+
+func TestVersionSortableString(t *testing.T) {
+	versions := []string{
+		"0.0.1", "1.0.0-dev", "1.0.0-alpha", "1.0.0-alpha2", "1.0.0-beta", "1.0.0-beta2",
+		"1.0.0-beta2.1", "1.0.0-beta10", "1.0.0-RC1", "1.0.0", "1.0.0.1", "1.0.1", "1.2", "10.0.0", "2.0.x-dev",
+		"dev-master", "20100102", "2010.01.02", "1.0.0-patch1", "1.0.0-b01",
+	}
+
+	var parsed Collection
+	for _, version := range versions {
+		v, err := NewVersion(version)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		parsed = append(parsed, v)
+	}
+
+	for _, version := range []string{"1.0.0-alpha.1", "1.0.0-rc.1", "1.0.0-0.3", "1.0.0"} {
+		v, _ := NewStrictVersion(version)
+		parsed = append(parsed, v)
+	}
+
+	keys := make(map[*Version]string, len(parsed))
+	for _, v := range parsed {
+		key, err := v.SortableString()
+		if !assert.NoError(t, err, "encoding %s", v.Original) {
+			return
+		}
+
+		assert.Len(t, key, 236)
+		keys[v] = key
+	}
+
+	for _, a := range parsed {
+		for _, b := range parsed {
+			assert.Equal(t, compare(a, b), compareStrings(keys[a], keys[b]), "%s compared to %s", a.Original, b.Original)
+		}
+	}
+
+	sort.Sort(parsed)
+	assert.Equal(t, "0.0.1", parsed[0].Original)
+}
+
+func TestVersionSortableStringFails(t *testing.T) {
+	cases := []struct {
+		version string
+		err     string
+	}{
+		{"1.0.0-x.7", "unable to encode version 1.0.0-x.7: the pre-release x.7 can't be ordered by stability"},
+		{"1.0.0-RC.1", "unable to encode version 1.0.0-RC.1: the pre-release RC.1 can't be ordered by stability"},
+		{"1.0.0-beta.x", "unable to encode version 1.0.0-beta.x: the pre-release identifier x is not numeric"},
+		{"1.0.0-0.1.2.3.4", "unable to encode version 1.0.0-0.1.2.3.4: more than 4 pre-release identifiers"},
+		{"1.0.0-beta.12345678901234567890", "unable to encode version 1.0.0-beta.12345678901234567890: the pre-release identifier 12345678901234567890 has more than 19 digits"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			v, err := NewStrictVersion(tc.version)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_, err = v.SortableString()
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	v, _ := NewVersion("dev-foo")
+	_, err := v.SortableString()
+	assert.EqualError(t, err, "unable to encode version dev-foo: branches have no fixed-width encoding")
+}
@@ -0,0 +1,299 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Stability is the stability of a version, like the Stability of a Version or the minimum stability of a
+// constraint. The stabilities are ordered on a ladder from dev to stable, which can be extended with
+// RegisterStability
+type Stability string
+
+const (
+	StabilityDev    Stability = "dev"
+	StabilityAlpha  Stability = "alpha"
+	StabilityBeta   Stability = "beta"
+	StabilityRC     Stability = "RC"
+	StabilityStable Stability = "stable"
+
+	// StabilityPatch is the stability of patch releases like 1.0-p1, it is not on the ladder
+	StabilityPatch Stability = "patch"
+)
+
+/*
+ Stability registry
+
+ Holds the ladder of stabilities and the regexes which match them. A registry is never modified once it is
+ created, RegisterStability replaces it under the lock, so parsing always uses a consistent registry.
+*/
+type stabilityRegistry struct {
+	// ladder lists the stabilities from the least to the most stable
+	ladder []Stability
+	// ranks maps the stabilities of the ladder to their fixed rank
+	ranks map[Stability]int
+	// names maps the lower case names and shorthands to their stability
+	names map[string]Stability
+
+	stabilityRegex  string
+	versionRegexC   *regexp.Regexp
+	dateTimeRegex   *regexp.Regexp
+	stabilityRegexC *regexp.Regexp
+
+	stabilityModifierRegex *regexp.Regexp
+	stabilityFlagRegex     *regexp.Regexp
+	tildeRegex             *regexp.Regexp
+	caretRegex             *regexp.Regexp
+	hyphenRegex            *regexp.Regexp
+}
+
+var (
+	stabilityMutex sync.RWMutex
+
+	// The built-in stabilities have fixed ranks with gaps for registered ones, the ranks are encoded by
+	// SortKey and SortableString so they must never change
+	registeredStabilities = newStabilityRegistry(
+		map[Stability]int{StabilityDev: 50, StabilityAlpha: 100, StabilityBeta: 150, StabilityRC: 200, StabilityStable: 250},
+		map[string]Stability{
+			"dev":    StabilityDev,
+			"alpha":  StabilityAlpha,
+			"a":      StabilityAlpha,
+			"beta":   StabilityBeta,
+			"b":      StabilityBeta,
+			"rc":     StabilityRC,
+			"stable": StabilityStable,
+			"patch":  StabilityPatch,
+			"pl":     StabilityPatch,
+			"p":      StabilityPatch,
+		},
+	)
+
+	stabilityNameRegex = regexp.MustCompile(`^[a-zA-Z]+$`)
+)
+
+// NewStability returns the stability of a name or shorthand like "b" for beta, ignoring the case
+func NewStability(name string) (Stability, error) {
+	if stability, ok := currentStabilities().names[strings.ToLower(name)]; ok {
+		return stability, nil
+	}
+
+	return "", fmt.Errorf("invalid stability %s", name)
+}
+
+// Stabilities returns the stabilities of the ladder from the least to the most stable
+func Stabilities() []Stability {
+	return append([]Stability{}, currentStabilities().ladder...)
+}
+
+/*
+ Register Stability
+
+ Adds a custom stability to the ladder directly above an existing one, so after RegisterStability("nightly",
+ StabilityDev) 1.0-nightly2 is parsed and ordered between 1.0-dev and 1.0-alpha1. The aliases are expanded
+ to the stability when a version is parsed. Names are matched ignoring the case, must only contain letters
+ and can't be registered twice.
+
+ The new stability gets the rank halfway between its neighbours, the ranks of the other stabilities never
+ change, so keys of SortKey and SortableString which are already stored stay valid. The rank depends on the
+ stabilities registered before, so they should always be registered in the same order, e.g. in an init
+ function. It fails if there is no rank left between the neighbours.
+*/
+func RegisterStability(name Stability, after Stability, aliases ...string) error {
+	stabilityMutex.Lock()
+	defer stabilityMutex.Unlock()
+
+	names := append([]string{string(name)}, aliases...)
+
+	for _, n := range names {
+		if !stabilityNameRegex.MatchString(n) {
+			return fmt.Errorf("invalid stability name %q, only letters are allowed", n)
+		}
+
+		if _, ok := registeredStabilities.names[strings.ToLower(n)]; ok {
+			return fmt.Errorf("the stability %s is already registered", n)
+		}
+	}
+
+	low, ok := registeredStabilities.ranks[after]
+
+	if !ok || StabilityStable == after {
+		return fmt.Errorf("unable to register %s after %s, it must be a registered stability below stable", name, after)
+	}
+
+	high := registeredStabilities.ranks[StabilityStable]
+
+	for _, rank := range registeredStabilities.ranks {
+		if rank > low && rank < high {
+			high = rank
+		}
+	}
+
+	if high-low < 2 {
+		return fmt.Errorf("unable to register %s after %s, there is no rank left", name, after)
+	}
+
+	ranks := make(map[Stability]int, len(registeredStabilities.ranks)+1)
+	for stability, rank := range registeredStabilities.ranks {
+		ranks[stability] = rank
+	}
+
+	ranks[name] = low + (high-low)/2
+
+	lookup := make(map[string]Stability, len(registeredStabilities.names)+len(names))
+	for n, stability := range registeredStabilities.names {
+		lookup[n] = stability
+	}
+
+	for _, n := range names {
+		lookup[strings.ToLower(n)] = name
+	}
+
+	registeredStabilities = newStabilityRegistry(ranks, lookup)
+
+	return nil
+}
+
+// Rank returns the fixed rank of the stability, from 50 for dev to 250 for stable. An empty stability is
+// stable, patch and unknown stabilities have the rank 0
+func (s Stability) Rank() int {
+	return currentStabilities().rank(s)
+}
+
+// Compare returns LessThan if the stability is less stable than the other one
+func (s Stability) Compare(other Stability) int {
+	return comparePart(s.Rank(), other.Rank())
+}
+
+func (s Stability) String() string {
+	return string(s)
+}
+
+func compareStability(a string, b string) int {
+	return Stability(a).Compare(Stability(b))
+}
+
+// rank returns the rank of a stability of the registry, like Stability.Rank
+func (r *stabilityRegistry) rank(s Stability) int {
+	if "" == s {
+		s = StabilityStable
+	}
+
+	return r.ranks[s]
+}
+
+// isKnown reports whether the stability is on the ladder
+func (r *stabilityRegistry) isKnown(stability string) bool {
+	_, ok := r.ranks[Stability(stability)]
+	return ok
+}
+
+// stabilityForRank is the inverse of Stability.Rank, the rank 0 is only used by patch versions and stable
+// versions have no stability
+func stabilityForRank(rank int) (string, bool) {
+	if 0 == rank {
+		return string(StabilityPatch), true
+	}
+
+	for stability, r := range currentStabilities().ranks {
+		if r != rank {
+			continue
+		}
+
+		if StabilityStable == stability {
+			return "", true
+		}
+
+		return string(stability), true
+	}
+
+	return "", false
+}
+
+func currentStabilities() *stabilityRegistry {
+	stabilityMutex.RLock()
+	defer stabilityMutex.RUnlock()
+
+	return registeredStabilities
+}
+
+func newStabilityRegistry(ranks map[Stability]int, names map[string]Stability) *stabilityRegistry {
+	r := &stabilityRegistry{ranks: ranks, names: names}
+
+	for stability := range ranks {
+		r.ladder = append(r.ladder, stability)
+	}
+
+	sort.Sort(byRank{r.ladder, ranks})
+
+	r.compileVersionRegexes()
+	r.compileConstraintRegexes()
+
+	return r
+}
+
+// alternatives returns all names and shorthands as a regex alternation, the longest first so "beta" is
+// matched before "b"
+func (r *stabilityRegistry) alternatives() string {
+	names := make([]string, 0, len(r.names))
+
+	for name := range r.names {
+		names = append(names, name)
+	}
+
+	sort.Sort(byLength(names))
+
+	return strings.Join(names, "|")
+}
+
+// flagAlternatives returns the names of the ladder as a regex alternation, for flags like @beta
+func (r *stabilityRegistry) flagAlternatives() string {
+	names := make([]string, 0, len(r.ladder))
+
+	for _, stability := range r.ladder {
+		names = append(names, string(stability))
+	}
+
+	sort.Sort(byLength(names))
+
+	return strings.Join(names, "|")
+}
+
+// byRank sorts stabilities from the least to the most stable
+type byRank struct {
+	ladder []Stability
+	ranks  map[Stability]int
+}
+
+func (b byRank) Len() int {
+	return len(b.ladder)
+}
+
+func (b byRank) Less(i, j int) bool {
+	return b.ranks[b.ladder[i]] < b.ranks[b.ladder[j]]
+}
+
+func (b byRank) Swap(i, j int) {
+	b.ladder[i], b.ladder[j] = b.ladder[j], b.ladder[i]
+}
+
+// byLength sorts strings from the longest to the shortest, and strings of the same length alphabetically
+type byLength []string
+
+func (b byLength) Len() int {
+	return len(b)
+}
+
+func (b byLength) Less(i, j int) bool {
+	if len(b[i]) != len(b[j]) {
+		return len(b[i]) > len(b[j])
+	}
+
+	return b[i] < b[j]
+}
+
+func (b byLength) Swap(i, j int) {
+	b[i], b[j] = b[j], b[i]
+}
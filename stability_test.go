@@ -0,0 +1,204 @@
+package semver
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// withStabilities restores the default ladder after a test registered custom stabilities
+func withStabilities(t *testing.T, test func(t *testing.T)) {
+	registry := currentStabilities()
+
+	defer func() {
+		stabilityMutex.Lock()
+		registeredStabilities = registry
+		stabilityMutex.Unlock()
+	}()
+
+	test(t)
+}
+
+func TestNewStability(t *testing.T) {
+	cases := []struct {
+		name      string
+		stability Stability
+		valid     bool
+	}{
+		{"dev", StabilityDev, true},
+		{"a", StabilityAlpha, true},
+		{"BETA", StabilityBeta, true},
+		{"rc", StabilityRC, true},
+		{"stable", StabilityStable, true},
+		{"pl", StabilityPatch, true},
+		{"nightly", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stability, err := NewStability(tc.name)
+
+			assert.Equal(t, tc.stability, stability)
+			assert.Equal(t, tc.valid, nil == err)
+		})
+	}
+}
+
+func TestStabilityCompare(t *testing.T) {
+	cases := []struct {
+		a      Stability
+		b      Stability
+		result int
+	}{
+		{StabilityDev, StabilityAlpha, LessThan},
+		{StabilityRC, StabilityBeta, GreaterThan},
+		{StabilityStable, "", Equal},
+		{StabilityPatch, StabilityDev, LessThan},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s %s", tc.a, tc.b), func(t *testing.T) {
+			assert.Equal(t, tc.result, tc.a.Compare(tc.b))
+		})
+	}
+}
+
+func TestRegisterStability(t *testing.T) {
+	stable, _ := NewVersion("1.0")
+	beta, _ := NewVersion("1.0-beta2")
+	stableKey, _ := stable.SortableString()
+	betaKey, _ := beta.SortKey()
+
+	withStabilities(t, func(t *testing.T) {
+		assert.Nil(t, RegisterStability("nightly", StabilityDev))
+		assert.Nil(t, RegisterStability("canary", "nightly", "snapshot"))
+
+		assert.Equal(t, []Stability{StabilityDev, "nightly", "canary", StabilityAlpha, StabilityBeta, StabilityRC, StabilityStable}, Stabilities())
+		assert.Equal(t, 75, Stability("nightly").Rank())
+		assert.Equal(t, 87, Stability("canary").Rank())
+
+		// the ranks of the other stabilities don't change, so stored keys stay valid
+		assert.Equal(t, 250, StabilityStable.Rank())
+
+		key, err := stable.SortableString()
+
+		assert.Nil(t, err)
+		assert.Equal(t, stableKey, key)
+
+		sortKey, err := beta.SortKey()
+
+		assert.Nil(t, err)
+		assert.Equal(t, betaKey, sortKey)
+
+		versions := []string{"1.0-alpha1", "1.0-canary1", "1.0-dev", "1.0-NIGHTLY2", "1.0-nightly1"}
+		sorted, err := Sort(versions)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"1.0-dev", "1.0-nightly1", "1.0-NIGHTLY2", "1.0-canary1", "1.0-alpha1"}, sorted)
+
+		v, err := NewVersion("1.0-SNAPSHOT3")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "canary", v.Stability)
+		assert.Equal(t, "1.0.0.0-canary3", v.String())
+		assert.Equal(t, "canary", ParseStability("1.0.0-snapshot.3"))
+
+		v, err = NewStrictVersion("1.0.0-nightly.2")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "nightly", v.Stability)
+
+		c, err := NewConstraint("^1.0@nightly")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "nightly", c.StabilityFlag())
+
+		matches, err := Satisfies("1.0-canary1", ">=1.0-canary")
+
+		assert.Nil(t, err)
+		assert.True(t, matches)
+
+		matches, err = Satisfies("1.0-nightly1", ">=1.0-canary")
+
+		assert.Nil(t, err)
+		assert.False(t, matches)
+
+		v, err = NewVersion("1.0-nightly2")
+
+		assert.Nil(t, err)
+
+		sortKey, err = v.SortKey()
+
+		assert.Nil(t, err)
+
+		decoded, err := DecodeSortKey(sortKey)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "1.0.0.0-nightly2", decoded.String())
+	})
+
+	assert.Equal(t, []Stability{StabilityDev, StabilityAlpha, StabilityBeta, StabilityRC, StabilityStable}, Stabilities())
+
+	_, err := NewVersion("1.0-nightly1")
+	assert.NotNil(t, err)
+}
+
+func TestRegisterStabilityConcurrently(t *testing.T) {
+	withStabilities(t, func(t *testing.T) {
+		done := make(chan bool)
+
+		go func() {
+			for i := 0; i < 100; i++ {
+				_, _ = NewConstraint("^1.0-beta2 || >=2.0@RC")
+			}
+
+			done <- true
+		}()
+
+		assert.NoError(t, RegisterStability("nightly", StabilityDev))
+		<-done
+	})
+}
+
+func TestRegisterStabilityErrors(t *testing.T) {
+	cases := []struct {
+		name    Stability
+		after   Stability
+		aliases []string
+		err     string
+	}{
+		{"beta", StabilityAlpha, nil, "the stability beta is already registered"},
+		{"nightly", StabilityDev, []string{"b"}, "the stability b is already registered"},
+		{"night-ly", StabilityDev, nil, `invalid stability name "night-ly", only letters are allowed`},
+		{"nightly", StabilityStable, nil, "unable to register nightly after stable, it must be a registered stability below stable"},
+		{"nightly", StabilityPatch, nil, "unable to register nightly after patch, it must be a registered stability below stable"},
+		{"nightly", "canary", nil, "unable to register nightly after canary, it must be a registered stability below stable"},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.name), func(t *testing.T) {
+			withStabilities(t, func(t *testing.T) {
+				err := RegisterStability(tc.name, tc.after, tc.aliases...)
+
+				if assert.NotNil(t, err) {
+					assert.Equal(t, tc.err, err.Error())
+				}
+
+				assert.Len(t, Stabilities(), 5)
+			})
+		})
+	}
+}
+
+func TestRegisterStabilityWithoutRank(t *testing.T) {
+	withStabilities(t, func(t *testing.T) {
+		// each stability gets the rank halfway between dev (50) and the last one: 75, 62, 56, 53 and 51
+		for _, name := range []Stability{"one", "two", "three", "four", "five"} {
+			assert.NoError(t, RegisterStability(name, StabilityDev))
+		}
+
+		assert.Equal(t, 51, Stability("five").Rank())
+		assert.EqualError(t, RegisterStability("six", StabilityDev), "unable to register six after dev, there is no rank left")
+	})
+}
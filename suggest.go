@@ -0,0 +1,155 @@
+package semver
+
+import (
+	"regexp"
+	"strings"
+)
+
+type suggestionRule struct {
+	regex       *regexp.Regexp
+	replacement string
+}
+
+var (
+	// Each rule rewrites one common mistake, they are applied in order so a constraint can contain several
+	suggestionRules = []suggestionRule{
+		// npm and JavaScript style "&&", Composer uses a space or comma
+		{regexp.MustCompile(`\s*&&\s*`), " "},
+		// pip's compatible release operator ~=1.4 and Ruby's pessimistic operator ~>1.4
+		{regexp.MustCompile(`~[=>]\s*`), "~"},
+		// a "v" in front of the operator, v>=1.0
+		{regexp.MustCompile(`(?i)(^|[\s,|])v\s*(<>|!=|>=?|<=?|==?|~|\^)`), "$1$2"},
+		// whitespace between a tilde or caret and the version, ^ 1.0
+		{regexp.MustCompile(`([~^])\s+`), "$1"},
+		// a "v" after the operator, ^v1.0
+		{regexp.MustCompile(`(?i)(<>|!=|>=?|<=?|==?|~|\^)\s*v(\d)`), "$1$2"},
+		// a wildcard followed by more segments, 1.*.3
+		{regexp.MustCompile(`(^|[\s,|^~<>=!])((?:\d+\.)*)[xX*](?:\.(?:\d+|[xX*]))+`), "$1$2*"},
+		// a wildcard combined with a tilde or caret, ^1.*
+		{regexp.MustCompile(`([~^]v?\d+(?:\.\d+)*)(?:\.[xX*])+`), "$1"},
+	}
+
+	// Stability words used by other ecosystems, mapped to their Composer stability
+	stabilityAliases = map[string]string{
+		"snapshot":  "dev",
+		"nightly":   "dev",
+		"preview":   "alpha",
+		"pre":       "alpha",
+		"milestone": "alpha",
+		"cr":        "RC",
+		"final":     "",
+		"release":   "",
+		"ga":        "",
+	}
+
+	unknownStabilityRegex = regexp.MustCompile(`(?i)([\d@])[._-]?(snapshot|nightly|preview|pre|milestone|cr|final|release|ga)(?:[._-]?(\d+))?\b`)
+
+	// Match a Maven version range, [1.0,2.0) or [1.0]
+	mavenRangeRegex = regexp.MustCompile(`^\s*(?:([\[(])\s*([^,\s\[\]()]*)\s*,\s*([^,\s\[\]()]*)\s*([\])])|\[\s*([^,\s\[\]()]+)\s*\])\s*(?:,|$)`)
+)
+
+/*
+ Suggest Constraint
+
+ Tries to rewrite an invalid constraint written with the syntax of another ecosystem, or with a common typo,
+ into a valid Composer constraint. The suggestion is only returned if it can be parsed, otherwise the
+ result is empty.
+*/
+func (r *stabilityRegistry) suggestConstraint(constraint string) string {
+	suggestion := constraint
+
+	if maven := suggestMavenRange(constraint); "" != maven {
+		suggestion = maven
+	}
+
+	for _, rule := range suggestionRules {
+		suggestion = rule.regex.ReplaceAllString(suggestion, rule.replacement)
+	}
+
+	suggestion = unknownStabilityRegex.ReplaceAllStringFunc(suggestion, r.replaceUnknownStability)
+	suggestion = strings.TrimSpace(suggestion)
+
+	if suggestion == strings.TrimSpace(constraint) {
+		return ""
+	}
+
+	if _, err := r.newConstraint(suggestion); nil != err {
+		return ""
+	}
+
+	return suggestion
+}
+
+// suggestMavenRange converts a Maven version range, e.g. [1.0,2.0),[3.0,) becomes >=1.0 <2.0 || >=3.0
+func suggestMavenRange(constraint string) string {
+	var groups []string
+
+	for "" != strings.TrimSpace(constraint) {
+		matches := mavenRangeRegex.FindStringSubmatch(constraint)
+
+		if nil == matches {
+			return ""
+		}
+
+		constraint = constraint[len(matches[0]):]
+
+		if "" != matches[5] {
+			groups = append(groups, matches[5])
+			continue
+		}
+
+		var bounds []string
+
+		if "" != matches[2] {
+			operator := ">="
+			if "(" == matches[1] {
+				operator = ">"
+			}
+
+			bounds = append(bounds, operator+matches[2])
+		}
+
+		if "" != matches[3] {
+			operator := "<="
+			if ")" == matches[4] {
+				operator = "<"
+			}
+
+			bounds = append(bounds, operator+matches[3])
+		}
+
+		if 0 == len(bounds) {
+			bounds = append(bounds, "*")
+		}
+
+		groups = append(groups, strings.Join(bounds, " "))
+	}
+
+	return strings.Join(groups, " || ")
+}
+
+// replaceUnknownStability maps a stability word of another ecosystem, e.g. 1.0-SNAPSHOT becomes 1.0-dev.
+// Words which are registered as a stability are kept
+func (r *stabilityRegistry) replaceUnknownStability(match string) string {
+	matches := unknownStabilityRegex.FindStringSubmatch(match)
+
+	if _, ok := r.names[strings.ToLower(matches[2])]; ok {
+		return match
+	}
+
+	stability := stabilityAliases[strings.ToLower(matches[2])]
+
+	if "@" == matches[1] {
+		if "" == stability {
+			stability = "stable"
+		}
+
+		return "@" + stability
+	}
+
+	if "" == stability {
+		return matches[1]
+	}
+
+	return matches[1] + "-" + stability + matches[3]
+}
@@ -0,0 +1,78 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConstraintSuggestion(t *testing.T) {
+	cases := []struct {
+		constraint string
+		suggestion string
+	}{
+		{">=1.0.0 && <2", ">=1.0.0 <2"},
+		{"^1.0 || >=2.0 && <3.0", "^1.0 || >=2.0 <3.0"},
+		{"~=1.4", "~1.4"},
+		{"~= 1.4.2", "~1.4.2"},
+		{"~>1.2", "~1.2"},
+		{"[1.0,2.0)", ">=1.0 <2.0"},
+		{"(1.0, 2.0]", ">1.0 <=2.0"},
+		{"(,2.0]", "<=2.0"},
+		{"[1.0]", "1.0"},
+		{"[1.0,2.0),[3.0,)", ">=1.0 <2.0 || >=3.0"},
+		{"v>=1.0", ">=1.0"},
+		{"v^1.0", "^1.0"},
+		{"^ v1.0", "^1.0"},
+		{"~ 1.0", "~1.0"},
+		{"1.*.3", "1.*"},
+		{"1.x.3 || 2.0", "1.* || 2.0"},
+		{"*.1", "*"},
+		{"^1.*", "^1"},
+		{"~1.2.*", "~1.2"},
+		{"1.0.0-SNAPSHOT", "1.0.0-dev"},
+		{"1.0-nightly", "1.0-dev"},
+		{"1.0-preview2", "1.0-alpha2"},
+		{"1.0.0-pre.1", "1.0.0-alpha1"},
+		{"1.0.0-cr1", "1.0.0-RC1"},
+		{"1.0.0.Final", "1.0.0"},
+		{"^1.0@snapshot", "^1.0@dev"},
+		{"v>= 1.0 && <2.0-SNAPSHOT", ">= 1.0 <2.0-dev"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			_, err := NewConstraint(tc.constraint)
+
+			parseErr, ok := err.(*ConstraintParseError)
+			if !assert.True(t, ok, "expected a *ConstraintParseError, got %T", err) {
+				return
+			}
+
+			assert.Equal(t, tc.suggestion, parseErr.Suggestion)
+
+			_, err = NewConstraint(parseErr.Suggestion)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConstraintSuggestionMessage(t *testing.T) {
+	_, err := NewConstraint(">=1.0 && <2.0")
+
+	assert.EqualError(t, err, `unable to parse constraint &&: unable to parse version &&, did you mean ">=1.0 <2.0"?`)
+}
+
+func TestConstraintWithoutSuggestion(t *testing.T) {
+	cases := []string{"foo bar", "^1.0-gamma", "[1.0,2.0", "1.0 && foo"}
+
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			_, err := NewConstraint(tc)
+
+			parseErr, ok := err.(*ConstraintParseError)
+			if assert.True(t, ok, "expected a *ConstraintParseError, got %T", err) {
+				assert.Equal(t, "", parseErr.Suggestion)
+			}
+		})
+	}
+}
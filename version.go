@@ -15,8 +15,10 @@ type Version struct {
 	Metadata                   string
 	Original                   string
 	Parsed                     string
+	Alias                      *Version
 	isDate                     bool
 	isBranch                   bool
+	isStrict                   bool
 }
 
 func (v *Version) major() int {
@@ -31,18 +33,90 @@ func (v *Version) patch() int {
 	return v.Patch
 }
 
-func (v *Version) pre() float32 {
-	return cast.ToFloat32(strings.Replace(v.PreRelease, v.Stability, "", -1))
+func (v *Version) extra() int {
+	return v.Extra
+}
+
+// numericParts returns all numeric segments of the version, for dates these are
+// the date and time components (2010.01.02)
+func (v *Version) numericParts() []int {
+	if !v.isDate {
+		return []int{v.major(), v.minor(), v.patch(), v.extra()}
+	}
+
+	segments := strings.Split(v.Parsed, ".")
+	parts := make([]int, 0, len(segments))
+
+	for _, segment := range segments {
+		parts = append(parts, parseVersionNumber(segment))
+	}
+
+	return parts
+}
+
+// PreReleaseIdentifiers returns the pre-release split into its identifiers, e.g. [2 1] for
+// 1.0.0-beta2.1, or [beta 2] for the strict version 1.0.0-beta.2
+func (v *Version) PreReleaseIdentifiers() []string {
+	if "" == v.PreRelease {
+		return []string{}
+	}
+
+	if v.isStrict {
+		return strings.Split(v.PreRelease, ".")
+	}
+
+	return strings.FieldsFunc(v.PreRelease, func(r rune) bool {
+		return '.' == r || '-' == r
+	})
+}
+
+// pre returns the pre-release identifiers which follow the stability, e.g. [2 1] for 1.0.0-beta2.1
+func (v *Version) pre() []string {
+	identifiers := v.PreReleaseIdentifiers()
+
+	if len(identifiers) > 0 && "" != v.Stability && currentStabilities().expandStability(identifiers[0]) == v.Stability {
+		return identifiers[1:]
+	}
+
+	return identifiers
 }
 
 func (v *Version) stability() string {
 	return v.Stability
 }
 
+// copy returns a copy of the version which shares nothing with it, including the inline alias
+func (v *Version) copy() *Version {
+	version := *v
+
+	if nil != v.Alias {
+		version.Alias = v.Alias.copy()
+	}
+
+	return &version
+}
+
+// Normalized returns the normalized version, e.g. 1.2.0.0-beta2 for v1.2-b2
+func (v *Version) Normalized() string {
+	return v.String()
+}
+
+// Pretty returns the version as it was written, e.g. v1.2-b2. Versions which weren't parsed, like the result
+// of NextMinor, are formatted the way they would be tagged
+func (v *Version) Pretty() string {
+	if "" != v.Original {
+		return v.Original
+	}
+
+	return v.prettyString()
+}
+
 func (v *Version) String() string {
 	var buf bytes.Buffer
 
-	if v.isBranch || v.isDate {
+	if v.isStrict {
+		v.strictString(&buf)
+	} else if v.isBranch || v.isDate {
 		v.branchString(&buf)
 	} else {
 		_, _ = fmt.Fprintf(&buf, cast.ToString(v.Major))
@@ -87,3 +161,15 @@ func (v *Version) branchString(buf *bytes.Buffer) {
 		fmt.Fprintf(buf, v.Metadata)
 	}
 }
+
+func (v *Version) strictString(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if v.PreRelease != "" {
+		fmt.Fprintf(buf, "-%s", v.PreRelease)
+	}
+
+	if v.Metadata != "" {
+		fmt.Fprintf(buf, "+%s", v.Metadata)
+	}
+}